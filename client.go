@@ -0,0 +1,142 @@
+// Package resellerclub bundles all ResellerClub API services behind a single client.
+//
+//	c, err := resellerclub.New(core.Config{ResellerID: id, APIKey: key}, resellerclub.WithRetry(core.DefaultRetryPolicy()))
+//	orderID, err := c.Domains().GetOrderID(ctx, "example.com")
+package resellerclub
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/mrehanabbasi/go-logicboxes/contact"
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/customer"
+	"github.com/mrehanabbasi/go-logicboxes/dns"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/domainforward"
+	"github.com/mrehanabbasi/go-logicboxes/general"
+	"github.com/mrehanabbasi/go-logicboxes/pricing"
+)
+
+// Client gives access to every API service through a shared core.Core.
+type Client struct {
+	core core.Core
+
+	domains    domain.Domain
+	dns        dns.DNS
+	contacts   contact.Contact
+	customers  customer.Customer
+	pricing    pricing.Pricing
+	forwarding domainforward.DomainForward
+
+	mu      sync.Mutex
+	general general.General
+}
+
+type options struct {
+	httpClient  *http.Client
+	middlewares []core.Middleware
+}
+
+// Option customises a Client.
+type Option func(cfg *core.Config, o *options)
+
+// WithHTTPClient sets the HTTP client used for API calls. Defaults to http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(_ *core.Config, o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithRetry sets the retry policy, overriding core.Config.Retry.
+func WithRetry(policy *core.RetryPolicy) Option {
+	return func(cfg *core.Config, _ *options) {
+		cfg.Retry = policy
+	}
+}
+
+// WithLogger enables logging of every API call, overriding core.Config.Logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *core.Config, _ *options) {
+		cfg.Logger = logger
+	}
+}
+
+// WithMiddleware appends middlewares wrapping every API call.
+func WithMiddleware(middlewares ...core.Middleware) Option {
+	return func(_ *core.Config, o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// New creates a Client. It validates the configuration but makes no network call.
+func New(cfg core.Config, opts ...Option) (*Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(&cfg, o)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return NewWithCore(core.New(cfg, o.httpClient, o.middlewares...)), nil
+}
+
+// NewWithCore creates a Client on top of an existing core.Core.
+func NewWithCore(c core.Core) *Client {
+	return &Client{
+		core:       c,
+		domains:    domain.New(c),
+		dns:        dns.New(c),
+		contacts:   contact.New(c),
+		customers:  customer.New(c),
+		pricing:    pricing.New(c),
+		forwarding: domainforward.New(c),
+	}
+}
+
+// Core returns the underlying core.Core.
+func (c *Client) Core() core.Core {
+	return c.core
+}
+
+func (c *Client) Domains() domain.Domain {
+	return c.domains
+}
+
+func (c *Client) DNS() dns.DNS {
+	return c.dns
+}
+
+func (c *Client) Contacts() contact.Contact {
+	return c.contacts
+}
+
+func (c *Client) Customers() customer.Customer {
+	return c.customers
+}
+
+func (c *Client) Pricing() pricing.Pricing {
+	return c.pricing
+}
+
+func (c *Client) Forwarding() domainforward.DomainForward {
+	return c.forwarding
+}
+
+// General returns the general service. The currency and country lists it needs are fetched on the
+// first call only; a failed fetch is retried on the next call.
+func (c *Client) General(ctx context.Context) (general.General, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.general != nil {
+		return c.general, nil
+	}
+	g, err := general.New(ctx, c.core)
+	if err != nil {
+		return nil, err
+	}
+	c.general = g
+	return g, nil
+}
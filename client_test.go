@@ -0,0 +1,72 @@
+package resellerclub_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	resellerclub "github.com/mrehanabbasi/go-logicboxes"
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/general"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	srv := rctest.NewServer()
+	defer srv.Close()
+
+	var calls int
+	counter := func(next core.Handler) core.Handler {
+		return func(ctx context.Context, r *core.Request) (*http.Response, error) {
+			calls++
+			return next(ctx, r)
+		}
+	}
+	c, err := resellerclub.New(srv.Config(), resellerclub.WithHTTPClient(srv.Client()), resellerclub.WithMiddleware(counter))
+	require.NoError(t, err)
+	require.Empty(t, srv.Calls())
+
+	_, err = c.Domains().GetOrderID(context.Background(), "example.com")
+	var apiErr *core.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 1, calls)
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	_, err := resellerclub.New(core.Config{BaseURL: "ftp://example.com"})
+	require.ErrorIs(t, err, core.ErrRcInvalidBaseURL)
+}
+
+func TestGeneralIsLazy(t *testing.T) {
+	srv := rctest.NewServer()
+	defer srv.Close()
+	var ok atomic.Bool
+	srv.Handle("currency/details", func(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+		if !ok.Load() {
+			rctest.WriteError(w, http.StatusServiceUnavailable, "maintenance")
+			return
+		}
+		rctest.WriteJSON(w, map[string]map[string]string{"USD": {"currencyunit": "100", "currencyname": "US Dollar"}})
+	})
+
+	c, err := resellerclub.New(srv.Config(), resellerclub.WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = c.General(ctx)
+	require.Error(t, err)
+
+	ok.Store(true)
+	g, err := c.General(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "US Dollar", g.CurrencyOf(general.IsoUSD).Name)
+	calls := len(srv.Calls())
+
+	again, err := c.General(ctx)
+	require.NoError(t, err)
+	require.Same(t, g, again)
+	require.Len(t, srv.Calls(), calls)
+}
@@ -51,11 +51,7 @@ func (c *contact) DotCAAgreement(ctx context.Context) (map[string]string, error)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	ret := map[string]string{}
@@ -173,11 +169,7 @@ func (c *contact) AddExtraDetails(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	boolResult, err := strconv.ParseBool(string(bytesResp))
@@ -228,11 +220,7 @@ func (c *contact) ValidateRegistrant(ctx context.Context, contactID string, elig
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	validation := RegistrantValidation{}
@@ -270,11 +258,7 @@ func (c *contact) Default(ctx context.Context, customerID string, types []Type)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	replacer := strings.NewReplacer("contact.", "", "entity.", "")
@@ -362,11 +346,7 @@ func (c *contact) SetDefault(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	return nil
@@ -400,11 +380,7 @@ func (c *contact) Search(ctx context.Context, criteria Criteria, offset, limit u
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	replacer := strings.NewReplacer("entity.", "", "contact.", "")
@@ -457,11 +433,7 @@ func (c *contact) Delete(ctx context.Context, contactID string) (*Action, error)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	ret := new(Action)
@@ -492,11 +464,7 @@ func (c *contact) Details(ctx context.Context, contactID string) (*Detail, error
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	ret := new(Detail)
@@ -533,11 +501,7 @@ func (c *contact) Add(ctx context.Context, details *Detail, attributes core.Enti
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	details.ID = string(bytesResp)
@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -29,11 +31,28 @@ type Config struct {
 	ResellerID   string
 	APIKey       string
 	IsProduction bool
+	// BaseURL overrides the API root, e.g. for another LogicBoxes-powered brand or a local stand-in.
+	// When empty, the production or test host is used depending on IsProduction.
+	BaseURL string
+	// Retry enables retries of failed calls. A nil policy performs every call exactly once.
+	Retry *RetryPolicy
+	// RateLimit is the number of requests per second allowed, with bursts of up to RateBurst. Zero disables it.
+	RateLimit float64
+	RateBurst int
+	// MaxConcurrency caps the number of in-flight requests. Zero means unlimited.
+	MaxConcurrency int
+	// Logger enables structured logging of every call, with credentials redacted.
+	Logger *slog.Logger
 }
 
 type core struct {
-	cfg    Config
-	client *http.Client
+	cfg      Config
+	client   *http.Client
+	limiter  *rateLimiter
+	inFlight semaphore
+	handler  Handler
+	baseURL  string
+	cfgErr   error
 }
 
 type JSONStatusResponse struct {
@@ -83,12 +102,38 @@ var (
 	ErrRcAPIUnsupportedMethod = errors.New("unsupported http method")
 	ErrRcOperationFailed      = errors.New("operation failed")
 	ErrRcInvalidCredential    = errors.New("invalid credential")
+	ErrRcInvalidBaseURL       = errors.New("invalid base url")
 )
 
 func (c *core) IsProduction() bool {
 	return c.cfg.IsProduction
 }
 
+// Validate checks that the configuration can be used to call the API.
+func (c Config) Validate() error {
+	_, err := c.apiRoot()
+	return err
+}
+
+func (c Config) apiRoot() (string, error) {
+	if c.BaseURL == "" {
+		return host[c.IsProduction], nil
+	}
+
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRcInvalidBaseURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w: %q must be an absolute http or https url", ErrRcInvalidBaseURL, c.BaseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%w: %q must not contain a query or fragment", ErrRcInvalidBaseURL, c.BaseURL)
+	}
+
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
 // URLValues godoc
 //
 //nolint:gocognit
@@ -154,12 +199,18 @@ func (c Criteria) URLValues() (url.Values, error) {
 	return urlValues, nil
 }
 
+// PrintResponse writes the indented JSON data to stdout.
 func PrintResponse(data []byte) error {
+	return FprintResponse(os.Stdout, data)
+}
+
+// FprintResponse writes the indented JSON data to w.
+func FprintResponse(w io.Writer, data []byte) error {
 	var buffer bytes.Buffer
 	if err := json.Indent(&buffer, data, "", "\t"); err != nil {
 		return err
 	}
-	if _, err := buffer.WriteTo(os.Stdout); err != nil {
+	if _, err := buffer.WriteTo(w); err != nil {
 		return err
 	}
 
@@ -167,30 +218,79 @@ func PrintResponse(data []byte) error {
 }
 
 func (c *core) CallAPI(ctx context.Context, method, namespace, apiName string, data url.Values) (*http.Response, error) {
-	urlPath := host[c.cfg.IsProduction] + "/" + namespace + "/" + apiName + ".json"
-	data.Add("auth-userid", c.cfg.ResellerID)
-	data.Add("api-key", c.cfg.APIKey)
+	if data == nil {
+		data = url.Values{}
+	}
+
+	return c.handler(ctx, &Request{
+		Method:    method,
+		Namespace: namespace,
+		APIName:   apiName,
+		Values:    data,
+	})
+}
 
-	if method != http.MethodGet && method != http.MethodPost {
+func (c *core) roundTrip(ctx context.Context, r *Request) (*http.Response, error) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
 		return nil, ErrRcAPIUnsupportedMethod
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, urlPath+"?"+data.Encode(), http.NoBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if c.cfgErr != nil {
+		return nil, c.cfgErr
+	}
+
+	urlPath := c.baseURL + "/" + r.Namespace + "/" + r.APIName + ".json"
+	data := r.Values
+	if data.Get("auth-userid") == "" {
+		data.Set("auth-userid", c.cfg.ResellerID)
+	}
+	if data.Get("api-key") == "" {
+		data.Set("api-key", c.cfg.APIKey)
 	}
 
-	return c.client.Do(req)
+	// POST parameters travel in a form-encoded body so that credentials never end up in URLs.
+	ctx = withEndpoint(ctx, r.Namespace, r.APIName)
+	encoded := data.Encode()
+	return c.doWithRetry(ctx, r.Method, func() (*http.Request, error) {
+		if r.Method == http.MethodPost {
+			req, err := http.NewRequestWithContext(ctx, r.Method, urlPath, strings.NewReader(encoded))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			return req, nil
+		}
+
+		req, err := http.NewRequestWithContext(ctx, r.Method, urlPath+"?"+encoded, http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		return req, nil
+	})
 }
 
-func New(cfg Config, client *http.Client) Core {
-	var c *http.Client
-	if client == nil {
+// New creates a Core. A nil client falls back to http.DefaultClient, and middlewares wrap every
+// CallAPI in the given order, the first one being the outermost.
+// An invalid BaseURL makes every call fail, use Config.Validate to detect it upfront.
+func New(cfg Config, client *http.Client, middlewares ...Middleware) Core {
+	c := client
+	if c == nil {
 		c = http.DefaultClient
 	}
 
-	return &core{
-		cfg:    cfg,
-		client: c,
+	baseURL, cfgErr := cfg.apiRoot()
+	cr := &core{
+		cfg:      cfg,
+		baseURL:  baseURL,
+		cfgErr:   cfgErr,
+		client:   c,
+		limiter:  newRateLimiter(cfg.RateLimit, cfg.RateBurst),
+		inFlight: newSemaphore(cfg.MaxConcurrency),
+	}
+	if cfg.Logger != nil {
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], NewLoggingMiddleware(cfg.Logger))
 	}
+	cr.handler = Chain(cr.roundTrip, middlewares...)
+
+	return cr
 }
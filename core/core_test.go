@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.NoError(t, Config{BaseURL: "https://httpapi.example.com/api/"}.Validate())
+
+	for _, baseURL := range []string{"httpapi.com/api", "ftp://httpapi.com", "https://", "https://httpapi.com/api?x=1", "://"} {
+		require.ErrorIs(t, Config{BaseURL: baseURL}.Validate(), ErrRcInvalidBaseURL, baseURL)
+	}
+}
+
+func TestCallAPIBaseURL(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`true`))
+	}))
+	defer srv.Close()
+
+	c := New(Config{BaseURL: srv.URL + "/api/"}, srv.Client())
+	resp, err := c.CallAPI(context.Background(), http.MethodGet, "domains", "available", url.Values{})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, "/api/domains/available.json", path)
+
+	c = New(Config{BaseURL: "not a url"}, nil)
+	_, err = c.CallAPI(context.Background(), http.MethodGet, "domains", "available", url.Values{}) //nolint:bodyclose
+	require.ErrorIs(t, err, ErrRcInvalidBaseURL)
+}
+
+func TestCallAPIPostSendsFormBody(t *testing.T) {
+	var (
+		rawQuery    string
+		contentType string
+		form        url.Values
+	)
+	c := newTestCore(t, func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		contentType = r.Header.Get("Content-Type")
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		_, _ = w.Write([]byte(`true`))
+	}, testRetryPolicy())
+
+	ctx := WithMutatingRetry(context.Background())
+	resp, err := c.CallAPI(ctx, http.MethodPost, "customers/v2", "change-password", url.Values{"passwd": {"hunter2"}})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Empty(t, rawQuery)
+	require.Equal(t, "application/x-www-form-urlencoded", contentType)
+	require.Equal(t, "hunter2", form.Get("passwd"))
+	require.Equal(t, "key", form.Get("api-key"))
+	require.Equal(t, "1", form.Get("auth-userid"))
+}
+
+func TestCallAPIGetKeepsQueryString(t *testing.T) {
+	var query url.Values
+	c := newTestCore(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`true`))
+	}, nil)
+
+	resp, err := c.CallAPI(context.Background(), http.MethodGet, "domains", "available", url.Values{"tlds": {"com"}})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, "com", query.Get("tlds"))
+	require.Equal(t, "key", query.Get("api-key"))
+}
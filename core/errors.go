@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors used to classify an APIError, usable with errors.Is.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrAuth              = errors.New("authentication failed")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrValidation        = errors.New("validation failed")
+)
+
+type endpointKey struct{}
+
+type endpoint struct {
+	namespace string
+	apiName   string
+}
+
+// APIError is returned when the API answers with a non-200 status code.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+	Namespace  string
+	APIName    string
+	Body       []byte
+	Kind       error
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return strings.ToLower(http.StatusText(e.StatusCode))
+	}
+	return strings.ToLower(e.Message)
+}
+
+// Unwrap returns the classification of the error, so errors.Is(err, ErrNotFound) works.
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// NewAPIError builds an APIError from a non-200 response and its already read body.
+func NewAPIError(resp *http.Response, body []byte) error {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+
+	statusResponse := JSONStatusResponse{}
+	if err := json.Unmarshal(body, &statusResponse); err == nil {
+		apiErr.Status = statusResponse.Status
+		apiErr.Message = statusResponse.Message
+	}
+
+	if resp.Request != nil {
+		if ep, ok := resp.Request.Context().Value(endpointKey{}).(endpoint); ok {
+			apiErr.Namespace = ep.namespace
+			apiErr.APIName = ep.apiName
+		}
+	}
+
+	apiErr.Kind = classify(apiErr.StatusCode, apiErr.Message)
+	return apiErr
+}
+
+func withEndpoint(ctx context.Context, namespace, apiName string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint{namespace: namespace, apiName: apiName})
+}
+
+func classify(statusCode int, message string) error {
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuth
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusPaymentRequired:
+		return ErrInsufficientFunds
+	}
+
+	msg := strings.ToLower(message)
+	switch {
+	case containsAny(msg, "insufficient fund", "insufficient balance", "not enough fund"):
+		return ErrInsufficientFunds
+	case containsAny(msg, "too many request", "rate limit", "throttl"):
+		return ErrRateLimited
+	case containsAny(msg, "authentication", "access denied", "unauthorized", "api-key", "auth-userid", "ip address"):
+		return ErrAuth
+	case containsAny(msg, "not found", "no entity found", "does not exist", "no record"):
+		return ErrNotFound
+	case containsAny(msg, "invalid", "required", "must be", "not allowed", "not a valid"):
+		return ErrValidation
+	}
+
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity {
+		return ErrValidation
+	}
+
+	return nil
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
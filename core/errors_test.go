@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIError(t *testing.T) {
+	req, err := http.NewRequestWithContext(withEndpoint(context.Background(), "domains", "details"), http.MethodGet, "/", http.NoBody)
+	require.NoError(t, err)
+
+	body := []byte(`{"status":"ERROR","message":"No Entity found for Entityid: 123"}`)
+	err = NewAPIError(&http.Response{StatusCode: http.StatusInternalServerError, Request: req}, body)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	require.Equal(t, "ERROR", apiErr.Status)
+	require.Equal(t, "No Entity found for Entityid: 123", apiErr.Message)
+	require.Equal(t, "domains", apiErr.Namespace)
+	require.Equal(t, "details", apiErr.APIName)
+	require.Equal(t, body, apiErr.Body)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, "no entity found for entityid: 123", err.Error())
+}
+
+func TestNewAPIErrorClassification(t *testing.T) {
+	testCases := []struct {
+		statusCode int
+		body       string
+		kind       error
+	}{
+		{http.StatusTooManyRequests, ``, ErrRateLimited},
+		{http.StatusUnauthorized, ``, ErrAuth},
+		{http.StatusInternalServerError, `{"status":"ERROR","message":"Insufficient funds in your account"}`, ErrInsufficientFunds},
+		{http.StatusInternalServerError, `{"status":"ERROR","message":"Invalid api-key or auth-userid"}`, ErrAuth},
+		{http.StatusInternalServerError, `{"status":"ERROR","message":"Invalid domain name"}`, ErrValidation},
+		{http.StatusBadGateway, `<html>Bad Gateway</html>`, nil},
+	}
+
+	for _, tc := range testCases {
+		err := NewAPIError(&http.Response{StatusCode: tc.statusCode}, []byte(tc.body))
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, tc.kind, apiErr.Kind, tc.body)
+		require.NotEmpty(t, err.Error())
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding/json"
 	"strconv"
 	"strings"
 	"time"
@@ -14,10 +15,35 @@ type (
 	JSONUint16    uint16
 	JSONInt       int
 	JSONBytes     []byte
+	JSONStrings   []string
 )
 
+// jsonScalar returns a JSON string or number as a string. The API sends null or an empty string
+// for unset fields, ok is false for those.
+func jsonScalar(b []byte) (s string, ok bool) {
+	s = strings.TrimSpace(string(b))
+	if s == "null" {
+		return "", false
+	}
+	s = strings.TrimSpace(strings.Trim(s, "\""))
+	return s, s != ""
+}
+
+// UnmarshalJSON accepts booleans, 0 and 1, yes and no, y and n, as JSON values or strings.
 func (j *JSONBool) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), "\"")
+	s, ok := jsonScalar(b)
+	if !ok {
+		*j = false
+		return nil
+	}
+	switch strings.ToLower(s) {
+	case "yes", "y":
+		*j = true
+		return nil
+	case "no", "n":
+		*j = false
+		return nil
+	}
 	bValue, err := strconv.ParseBool(s)
 	if err != nil {
 		return err
@@ -31,7 +57,11 @@ func (j JSONBool) ToBool() bool {
 }
 
 func (j *JSONFloat) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), "\"")
+	s, ok := jsonScalar(b)
+	if !ok {
+		*j = 0
+		return nil
+	}
 	fValue, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		return err
@@ -44,12 +74,22 @@ func (j JSONFloat) ToFloat64() float64 {
 	return float64(j)
 }
 
+// UnmarshalJSON accepts a Unix time as a number or a string. Zero, null and an empty string are
+// the zero time.
 func (j *JSONTime) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), "\"")
+	s, ok := jsonScalar(b)
+	if !ok {
+		*j = JSONTime{}
+		return nil
+	}
 	tValue, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
 		return err
 	}
+	if tValue == 0 {
+		*j = JSONTime{}
+		return nil
+	}
 	*j = JSONTime(time.Unix(tValue, 0))
 	return nil
 }
@@ -73,7 +113,11 @@ func (j JSONTimestamp) ToTime() time.Time {
 }
 
 func (j *JSONUint16) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), "\"")
+	s, ok := jsonScalar(b)
+	if !ok {
+		*j = 0
+		return nil
+	}
 	tValue, err := strconv.ParseInt(s, 10, 16)
 	if err != nil {
 		return err
@@ -87,7 +131,11 @@ func (j JSONUint16) ToUint16() uint16 {
 }
 
 func (j *JSONInt) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), "\"")
+	s, ok := jsonScalar(b)
+	if !ok {
+		*j = 0
+		return nil
+	}
 	tValue, err := strconv.ParseInt(s, 10, 16)
 	if err != nil {
 		return err
@@ -104,3 +152,20 @@ func (j *JSONBytes) UnmarshalJSON(b []byte) error {
 	*j = JSONBytes([]byte(strings.Trim(string(b), "\"")))
 	return nil
 }
+
+// UnmarshalJSON accepts an array of strings or a single string, which the API sends for lists with
+// one element. An empty string or null is an empty list.
+func (j *JSONStrings) UnmarshalJSON(b []byte) error {
+	var values []string
+	if err := json.Unmarshal(b, &values); err == nil {
+		*j = values
+		return nil
+	}
+	s, ok := jsonScalar(b)
+	if !ok {
+		*j = nil
+		return nil
+	}
+	*j = JSONStrings{s}
+	return nil
+}
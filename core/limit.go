@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket refilled at rate tokens per second, holding up to burst tokens.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// semaphore caps the number of in-flight requests. A nil semaphore never blocks.
+type semaphore chan struct{}
+
+func newSemaphore(size int) semaphore {
+	if size <= 0 {
+		return nil
+	}
+	return make(semaphore, size)
+}
+
+func (s semaphore) Acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s semaphore) Release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// releaseOnClose releases a semaphore slot once the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+// send performs a single HTTP round trip under the client's rate and concurrency limits.
+func (c *core) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.inFlight.Acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.inFlight.Release()
+		return nil, redactURLError(err)
+	}
+	if c.inFlight != nil {
+		resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: c.inFlight.Release}
+	}
+	return resp, nil
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := newRateLimiter(100, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, limiter.Wait(context.Background()))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+
+	slow := newRateLimiter(0.001, 1)
+	require.NoError(t, slow.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, slow.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestCallAPIMaxConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(`true`))
+	}
+	c := newTestCore(t, srv, nil)
+	c.(*core).inFlight = newSemaphore(2)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.CallAPI(context.Background(), http.MethodGet, "domains", "available", url.Values{})
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, peak.Load(), int32(2))
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveParams = map[string]bool{
+	"api-key":     true,
+	"auth-userid": true,
+	"passwd":      true,
+	"password":    true,
+	"auth-code":   true,
+	"otp":         true,
+	"token":       true,
+}
+
+// IsSensitiveParam reports whether the value of the query parameter must never be logged.
+func IsSensitiveParam(name string) bool {
+	name = strings.ToLower(name)
+	if sensitiveParams[name] {
+		return true
+	}
+	return strings.Contains(name, "passw") || strings.Contains(name, "token") ||
+		strings.Contains(name, "secret") || strings.HasSuffix(name, "otp")
+}
+
+// RedactValues returns a copy of values with sensitive parameters replaced.
+func RedactValues(values url.Values) url.Values {
+	ret := make(url.Values, len(values))
+	for key, vals := range values {
+		if IsSensitiveParam(key) {
+			ret[key] = []string{redacted}
+			continue
+		}
+		ret[key] = append([]string(nil), vals...)
+	}
+	return ret
+}
+
+// redactURLError redacts the sensitive query parameters of the URL held by a transport error,
+// GET calls carry the credentials in it.
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	u, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil {
+		return &url.Error{Op: urlErr.Op, URL: redacted, Err: urlErr.Err}
+	}
+	u.RawQuery = RedactValues(u.Query()).Encode()
+	return &url.Error{Op: urlErr.Op, URL: u.String(), Err: urlErr.Err}
+}
+
+// NewLoggingMiddleware logs every API call with its parameters, latency, status code and error class.
+// Sensitive parameters are redacted.
+func NewLoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("namespace", req.Namespace),
+				slog.String("api_name", req.APIName),
+				slog.String("params", RedactValues(req.Values).Encode()),
+				slog.Duration("latency", time.Since(start)),
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case err != nil:
+				level = slog.LevelError
+				attrs = append(attrs, slog.String("error_class", errorClass(err)), slog.String("error", err.Error()))
+			case resp.StatusCode != http.StatusOK:
+				level = slog.LevelWarn
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+				body, readErr := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				if readErr == nil {
+					attrs = append(attrs, slog.String("error_class", errorClass(NewAPIError(resp, body))))
+				}
+			default:
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			}
+
+			logger.LogAttrs(ctx, level, "resellerclub api call", attrs...)
+			return resp, err
+		}
+	}
+}
+
+func errorClass(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrAuth):
+		return "auth"
+	case errors.Is(err, ErrInsufficientFunds):
+		return "insufficient_funds"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrValidation):
+		return "validation"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return "api"
+	}
+	return "transport"
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactValues(t *testing.T) {
+	values := url.Values{
+		"api-key":      {"secret-key"},
+		"passwd":       {"p4ssw0rd"},
+		"new-passwd":   {"p4ssw0rd"},
+		"auth-code":    {"abc"},
+		"otp":          {"123456"},
+		"token":        {"tok"},
+		"domain-name":  {"example.com"},
+		"auth-userid":  {"1"},
+		"customer-id":  {"2"},
+		"login-token":  {"tok"},
+		"domain-names": {"a", "b"},
+	}
+
+	redactedValues := RedactValues(values)
+	for _, key := range []string{"api-key", "passwd", "new-passwd", "auth-code", "otp", "token", "login-token"} {
+		require.Equal(t, []string{redacted}, redactedValues[key], key)
+	}
+	require.Equal(t, "example.com", redactedValues.Get("domain-name"))
+	require.Equal(t, []string{"a", "b"}, redactedValues["domain-names"])
+	require.Equal(t, "secret-key", values.Get("api-key"))
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	body := `{"status":"ERROR","message":"Invalid password"}`
+	c := newTestCore(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(body))
+	}, nil, NewLoggingMiddleware(logger))
+
+	resp, err := c.CallAPI(context.Background(), http.MethodPost, "customers/v2", "change-password",
+		url.Values{"passwd": {"hunter2"}, "api-key": {"key"}})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, body, string(respBody))
+
+	logged := buf.String()
+	require.NotContains(t, logged, "hunter2")
+	require.NotContains(t, logged, "api-key=key")
+	require.Contains(t, logged, `"namespace":"customers/v2"`)
+	require.Contains(t, logged, `"api_name":"change-password"`)
+	require.Contains(t, logged, `"status":500`)
+	require.Contains(t, logged, `"error_class":"validation"`)
+	require.Contains(t, logged, `"latency"`)
+}
+
+func TestLoggingMiddlewareRedactsTransportErrors(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	c := New(Config{ResellerID: "12345", APIKey: "secret-key", BaseURL: "http://127.0.0.1:1"}, nil,
+		NewLoggingMiddleware(logger))
+
+	_, err := c.CallAPI(context.Background(), http.MethodGet, "domains", "available", //nolint:bodyclose
+		url.Values{"domain-name": {"example.com"}})
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "secret-key")
+	require.NotContains(t, err.Error(), "12345")
+	require.Contains(t, err.Error(), "domain-name=example.com")
+
+	logged := buf.String()
+	require.NotContains(t, logged, "secret-key")
+	require.NotContains(t, logged, "12345")
+	require.Contains(t, logged, `"error_class":"transport"`)
+}
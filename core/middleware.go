@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Request describes a single API call as seen by middlewares.
+//
+// Values does not contain the reseller credentials, those are added after the middleware chain
+// unless a middleware already set auth-userid or api-key.
+type Request struct {
+	Method    string
+	Namespace string
+	APIName   string
+	Values    url.Values
+}
+
+// Handler performs an API call.
+type Handler func(ctx context.Context, req *Request) (*http.Response, error)
+
+// Middleware wraps a Handler to add behavior around API calls, e.g. logging, metrics or tracing.
+type Middleware func(next Handler) Handler
+
+// Chain composes middlewares so that the first one is the outermost.
+func Chain(h Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			h = middlewares[i](h)
+		}
+	}
+	return h
+}
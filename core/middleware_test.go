@@ -0,0 +1,60 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	var order []string
+	var seen *Request
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*http.Response, error) {
+				order = append(order, name)
+				seen = req
+				return next(ctx, req)
+			}
+		}
+	}
+	inject := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			req.Values.Set("api-key", "injected")
+			return next(ctx, req)
+		}
+	}
+
+	var query url.Values
+	c := newTestCore(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`true`))
+	}, nil, trace("outer"), trace("inner"), inject)
+
+	resp, err := c.CallAPI(context.Background(), http.MethodGet, "domains", "available", url.Values{"tlds": {"com"}})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, []string{"outer", "inner"}, order)
+	require.Equal(t, http.MethodGet, seen.Method)
+	require.Equal(t, "domains", seen.Namespace)
+	require.Equal(t, "available", seen.APIName)
+	require.Equal(t, "com", query.Get("tlds"))
+	require.Equal(t, "injected", query.Get("api-key"))
+	require.Equal(t, "1", query.Get("auth-userid"))
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	stub := func(Handler) Handler {
+		return func(context.Context, *Request) (*http.Response, error) {
+			return nil, ErrRcOperationFailed
+		}
+	}
+	c := New(Config{}, nil, stub)
+
+	_, err := c.CallAPI(context.Background(), http.MethodGet, "domains", "available", nil) //nolint:bodyclose
+	require.ErrorIs(t, err, ErrRcOperationFailed)
+}
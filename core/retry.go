@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy describes how failed calls are retried by CallAPI.
+//
+// Only GET calls are retried unless RetryMutating is set, or the call's context
+// was created with WithMutatingRetry.
+type RetryPolicy struct {
+	MaxAttempts          int
+	BaseBackoff          time.Duration
+	MaxBackoff           time.Duration
+	Jitter               float64 // fraction of the backoff, in range of 0 to 1
+	RetryableStatusCodes []int
+	RetryMutating        bool
+}
+
+type mutatingRetryKey struct{}
+
+// DefaultRetryPolicy returns a policy retrying up to 4 attempts on 429, 502, 503, 504 and transient network errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithMutatingRetry marks a call as safe to retry even though it is not a GET.
+func WithMutatingRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mutatingRetryKey{}, true)
+}
+
+func (p *RetryPolicy) allows(ctx context.Context, method string) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	if method == http.MethodGet || p.RetryMutating {
+		return true
+	}
+	optIn, _ := ctx.Value(mutatingRetryKey{}).(bool)
+	return optIn
+}
+
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay := time.Duration(seconds) * time.Second
+			if p.MaxBackoff > 0 {
+				delay = min(delay, p.MaxBackoff)
+			}
+			return delay
+		}
+	}
+
+	delay := p.BaseBackoff << (attempt - 1)
+	if delay <= 0 || (p.MaxBackoff > 0 && delay > p.MaxBackoff) {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(delay)) //nolint:gosec
+	}
+	return max(delay, 0)
+}
+
+func (p *RetryPolicy) retryableResponse(resp *http.Response) bool {
+	return slices.Contains(p.RetryableStatusCodes, resp.StatusCode)
+}
+
+func retryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func (c *core) doWithRetry(ctx context.Context, method string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	policy := c.cfg.Retry
+	if !policy.allows(ctx, method) {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		return c.send(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.send(req)
+		lastAttempt := attempt >= policy.MaxAttempts
+		switch {
+		case err != nil:
+			if lastAttempt || !retryableError(err) {
+				return nil, err
+			}
+		case !policy.retryableResponse(resp) || lastAttempt:
+			return resp, nil
+		default:
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt, resp))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCore(t *testing.T, handler http.HandlerFunc, retry *RetryPolicy, middlewares ...Middleware) Core {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return New(Config{ResellerID: "1", APIKey: "key", BaseURL: srv.URL, Retry: retry}, srv.Client(), middlewares...)
+}
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestCallAPIRetriesReads(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestCore(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`true`))
+	}, testRetryPolicy())
+
+	resp, err := c.CallAPI(context.Background(), http.MethodGet, "domains", "available", url.Values{})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.EqualValues(t, 3, calls.Load())
+}
+
+func TestCallAPIDoesNotRetryMutatingByDefault(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestCore(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, testRetryPolicy())
+
+	resp, err := c.CallAPI(context.Background(), http.MethodPost, "domains", "register", url.Values{})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	require.EqualValues(t, 1, calls.Load())
+
+	resp, err = c.CallAPI(WithMutatingRetry(context.Background()), http.MethodPost, "domains", "register", url.Values{})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.EqualValues(t, 1+testRetryPolicy().MaxAttempts, calls.Load())
+}
+
+func TestCallAPIWithoutRetryPolicy(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestCore(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, nil)
+
+	resp, err := c.CallAPI(context.Background(), http.MethodGet, "domains", "available", url.Values{})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.EqualValues(t, 1, calls.Load())
+}
+
+func TestCallAPIRetryHonoursContext(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.BaseBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+	c := newTestCore(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.CallAPI(ctx, http.MethodGet, "domains", "available", url.Values{}) //nolint:bodyclose
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryBackoffHonoursRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+
+	policy := &RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}
+	require.Equal(t, 3*time.Second, policy.backoff(1, resp))
+
+	policy.MaxBackoff = time.Second
+	require.Equal(t, time.Second, policy.backoff(1, resp))
+}
@@ -0,0 +1,296 @@
+package core
+
+import (
+	"slices"
+	"strings"
+)
+
+// TLDInfo describes a TLD sold under a product key. The values are the usual registry policies and
+// can be adjusted for a reseller account with NewTLDCatalog.
+type TLDInfo struct {
+	// TLD is the suffix without the leading dot, in A-label form, e.g. "co.uk" or "xn--p1ai".
+	TLD string
+	Key DomainKey
+	// MinYears and MaxYears bound the registration and renewal terms.
+	MinYears int
+	MaxYears int
+	// Privacy reports whether privacy protection can be purchased.
+	Privacy bool
+	// TheftProtection reports whether the theft protection lock is supported.
+	TheftProtection bool
+	// TransferAuthCode reports whether transfers in require an auth code.
+	TransferAuthCode bool
+	// ContactAttributes are the extra attributes the registry requires on contacts.
+	ContactAttributes []string
+	// IDNLanguage reports whether IDN registrations require a language attribute.
+	IDNLanguage bool
+	// RenewGraceDays is the number of days after expiry during which the domain can still be renewed.
+	RenewGraceDays int
+	// RestoreDays is the number of days after the grace period during which a deleted domain can
+	// be restored.
+	RestoreDays int
+}
+
+// Const for the grace periods of most generic TLDs, also assumed for TLDs missing from a catalog.
+const (
+	DefaultRenewGraceDays = 30
+	DefaultRestoreDays    = 30
+)
+
+// TLDCatalog maps TLDs to their product keys and policies.
+type TLDCatalog struct {
+	byTLD map[string]TLDInfo
+	byKey map[DomainKey][]string
+}
+
+// NewTLDCatalog builds a catalog. Later entries replace earlier ones with the same TLD, so a
+// reseller can override the defaults with NewTLDCatalog(append(DefaultTLDCatalog().All(), ...)).
+func NewTLDCatalog(tlds []TLDInfo) *TLDCatalog {
+	c := &TLDCatalog{byTLD: map[string]TLDInfo{}, byKey: map[DomainKey][]string{}}
+	for _, info := range tlds {
+		info.TLD = normalizeTLD(info.TLD)
+		if old, ok := c.byTLD[info.TLD]; ok {
+			c.byKey[old.Key] = slices.DeleteFunc(c.byKey[old.Key], func(tld string) bool { return tld == info.TLD })
+		}
+		info.ContactAttributes = slices.Clone(info.ContactAttributes)
+		c.byTLD[info.TLD] = info
+		c.byKey[info.Key] = append(c.byKey[info.Key], info.TLD)
+	}
+	for _, tlds := range c.byKey {
+		slices.Sort(tlds)
+	}
+	return c
+}
+
+var defaultTLDCatalog = NewTLDCatalog(defaultTLDs())
+
+// DefaultTLDCatalog returns the catalog of the TLDs with a DomainKey constant. Keys of TLD groups,
+// such as DotDonutsGroup1, are not mapped.
+func DefaultTLDCatalog() *TLDCatalog {
+	return defaultTLDCatalog
+}
+
+// Lookup returns the TLD, with or without its leading dot.
+func (c *TLDCatalog) Lookup(tld string) (TLDInfo, bool) {
+	info, ok := c.byTLD[normalizeTLD(tld)]
+	info.ContactAttributes = slices.Clone(info.ContactAttributes)
+	return info, ok
+}
+
+// LookupDomain returns the longest TLD of the catalog the domain name ends with.
+func (c *TLDCatalog) LookupDomain(domainName string) (TLDInfo, bool) {
+	labels := strings.Split(normalizeTLD(domainName), ".")
+	for i := 1; i < len(labels); i++ {
+		if info, ok := c.Lookup(strings.Join(labels[i:], ".")); ok {
+			return info, true
+		}
+	}
+	return TLDInfo{}, false
+}
+
+// TLDs returns the sorted TLDs sold under key.
+func (c *TLDCatalog) TLDs(key DomainKey) []string {
+	return slices.Clone(c.byKey[key])
+}
+
+// All returns every TLD of the catalog, sorted by TLD.
+func (c *TLDCatalog) All() []TLDInfo {
+	ret := make([]TLDInfo, 0, len(c.byTLD))
+	for _, info := range c.byTLD {
+		info.ContactAttributes = slices.Clone(info.ContactAttributes)
+		ret = append(ret, info)
+	}
+	slices.SortFunc(ret, func(a, b TLDInfo) int { return strings.Compare(a.TLD, b.TLD) })
+	return ret
+}
+
+// TLDs returns the TLDs of the key in the default catalog.
+func (k DomainKey) TLDs() []string {
+	return defaultTLDCatalog.TLDs(k)
+}
+
+func normalizeTLD(tld string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tld)), ".")
+}
+
+// gTLD returns the policies shared by most generic TLDs.
+func gTLD(tld string, key DomainKey) TLDInfo {
+	return TLDInfo{
+		TLD:              tld,
+		Key:              key,
+		MinYears:         1,
+		MaxYears:         10,
+		Privacy:          true,
+		TheftProtection:  true,
+		TransferAuthCode: true,
+		RenewGraceDays:   DefaultRenewGraceDays,
+		RestoreDays:      DefaultRestoreDays,
+	}
+}
+
+// ccTLD returns the policies shared by most country code TLDs.
+func ccTLD(tld string, key DomainKey) TLDInfo {
+	info := gTLD(tld, key)
+	info.Privacy = false
+	info.TheftProtection = false
+	return info
+}
+
+func tldWith(info TLDInfo, fn func(*TLDInfo)) TLDInfo {
+	fn(&info)
+	return info
+}
+
+func defaultTLDs() []TLDInfo {
+	var ret []TLDInfo
+	for _, key := range []DomainKey{
+		DotBERLIN, DotBID, DotBEST, DotBUZZ, DotCLUB, DotCOOP, DotDESI, DotMOBI, DotNAME,
+		DotNYC, DotOOO, DotQUEBEC, DotTRADE, DotUNO, DotWEBCAM, DotXXX, DotACCOUNTANTS,
+		DotCASINO, DotCREDIT, DotCREDITCARD, DotENERGY, DotGOLD, DotINVESTMENTS, DotLOANS, DotPICTURES,
+		DotTIRES, DotACTOR, DotAIRFORCE, DotARMY, DotATTORNEY, DotAUCTION, DotBAND, DotCONSULTING,
+		DotDANCE, DotDEGREE, DotDEMOCRAT, DotDENTIST, DotENGINEER, DotFORSALE, DotFUTBOL, DotGIVES,
+		DotHAUS, DotIMMOBILIEN, DotKAUFEN, DotLAWYER, DotLIVE, DotMARKET, DotMODA, DotMORTGAGE, DotNAVY,
+		DotNEWS, DotNINJA, DotPUB, DotREHAB, DotREPUBLICAN, DotREVIEWS, DotRIP, DotROCKS, DotSALE,
+		DotSOCIAL, DotVET, DotVIDEO, DotBLACK, DotBLUE, DotGLOBAL, DotGREEN, DotKIM, DotLIGHT, DotPINK,
+		DotPOKER, DotRED, DotSHIKSHA, DotVEGAS, DotVOTE, DotVOTO, DotBEER, DotCASA, DotCOOKING, DotCOUNTRY,
+		DotFASHION, DotFISHING, DotFIT, DotGARDEN, DotHORSE, DotKIWI, DotLONDON, DotRODEO, DotSURF,
+		DotWEDDING, DotWORK, DotYOGA, DotAUDIO, DotBLACKFRIDAY, DotCLICK, DotDIET, DotFLOWERS, DotGAME,
+		DotGIFT, DotGUITARS, DotHELP, DotHIPHOP, DotHOSTING, DotJUEGOS, DotLINK, DotLOL, DotPHOTO, DotPICS,
+		DotPROPERTY, DotSEXY, DotTATOO, DotBUILD, DotLUXURY, DotMEN, DotMENU, DotONE, DotBAR, DotCOLLEGE,
+		DotDESIGN, DotONLINE, DotPRESS, DotRENT, DotREST, DotSITE, DotSPACE, DotWEBSITE, DotCAREER,
+		DotJOBS, DotMARKETS, DotADULT, DotAMSTERDAM, DotARCHI, DotBIO, DotCAPETOWN, DotCYMRU, DotDURBAN,
+		DotIRISH, DotJOBURG, DotNAGOYA, DotPORN, DotSKI, DotSOFTWARE, DotSOY, DotSTUDIO, DotTIROL,
+		DotTOKYO, DotTOP, DotWALES, DotWANG, DotWIKI, DotXYZ, DotPHOTOGRAPHY, DotSYSTEMS, DotCENTER,
+		DotEMAIL, DotCOMPANY, DotSOLUTIONS, DotTIP, DotTODAY, DotCITY, DotBUSINESS, DotASSOCIATES, DotBIKE,
+		DotPLUMBING, DotGURU, DotCAMERA, DotACADEMY, DotWORLD, DotTOYS, DotCAMP, DotKITCHEN, DotSHOES,
+		DotBARGAINS, DotCOFFEE, DotGLASS, DotSOLAR, DotZONE, DotCOOL, DotWORKS, DotCLEANING, DotTOOLS,
+		DotCASH, DotLIFE, DotDOG, DotCOUPONS,
+	} {
+		ret = append(ret, gTLD(strings.TrimPrefix(string(key), "dot"), key))
+	}
+
+	idn := func(info *TLDInfo) { info.IDNLanguage = true }
+	noPrivacy := func(info *TLDInfo) { info.Privacy = false }
+	ret = append(ret,
+		tldWith(gTLD("com", DotCOM), idn),
+		tldWith(gTLD("net", DotNET), idn),
+		tldWith(gTLD("org", DotORG), idn),
+		tldWith(gTLD("info", DotINFO), idn),
+		tldWith(gTLD("biz", DotBIZ), idn),
+		tldWith(gTLD("asia", DotASIA), func(info *TLDInfo) { info.ContactAttributes = []string{"cedcontactid"} }),
+		tldWith(gTLD("tel", DotTEL), noPrivacy),
+		tldWith(gTLD("pro", DotPRO), func(info *TLDInfo) { info.ContactAttributes = []string{"profession"} }),
+		tldWith(gTLD("us", DotUS), func(info *TLDInfo) {
+			info.Privacy = false
+			info.ContactAttributes = []string{"purpose", "category"}
+		}),
+		gTLD("xn--3ds443g", DotChineseONLINE),
+		gTLD("xn--6frz82g", DotChineseMOBILE),
+		gTLD("xn--5tzm5g", DotChineseWEBSITE),
+		gTLD("xn--ngbc5azd", DotSHABAKA),
+		gTLD("xn--c1avg", DotRussianORG),
+		gTLD("xn--nqv7fs00ema", DotChineseORG),
+		tldWith(ccTLD("xn--h2brj9c", DotHindiBHARAT), idn),
+		gTLD("xn--i1b6b1a6a2e", DotHindiORG),
+
+		gTLD("cn.com", DotCNDotCOM),
+		gTLD("co.com", DotCODotCOM),
+		gTLD("com.de", DotCOMDotDE),
+		gTLD("us.com", DotUSDotCOM),
+		gTLD("uk.com", DotUKDotCOM),
+		gTLD("za.com", DotZADotCOM),
+		gTLD("in.net", DotINDotNET),
+		gTLD("la", DotLA),
+
+		tldWith(ccTLD("cc", DotCC), idn),
+		tldWith(ccTLD("tv", DotTV), idn),
+		ccTLD("bz", DotBZ),
+		ccTLD("co.bz", DotBZ3rd),
+		ccTLD("com.bz", DotBZ3rd),
+		ccTLD("net.bz", DotBZ3rd),
+		ccTLD("org.bz", DotBZ3rd),
+		tldWith(ccTLD("ca", DotCA), func(info *TLDInfo) { info.ContactAttributes = []string{"CPR"} }),
+		tldWith(ccTLD("cn", DotCN), func(info *TLDInfo) { info.MaxYears = 5 }),
+		tldWith(ccTLD("com.cn", DotCN3rd), func(info *TLDInfo) { info.MaxYears = 5 }),
+		tldWith(ccTLD("net.cn", DotCN3rd), func(info *TLDInfo) { info.MaxYears = 5 }),
+		tldWith(ccTLD("org.cn", DotCN3rd), func(info *TLDInfo) { info.MaxYears = 5 }),
+		tldWith(ccTLD("co", DotCO), func(info *TLDInfo) { info.MaxYears = 5 }),
+		tldWith(ccTLD("com.co", DotCO3rd), func(info *TLDInfo) { info.MaxYears = 5 }),
+		tldWith(ccTLD("net.co", DotCO3rd), func(info *TLDInfo) { info.MaxYears = 5 }),
+		tldWith(ccTLD("nom.co", DotCO3rd), func(info *TLDInfo) { info.MaxYears = 5 }),
+		tldWith(ccTLD("de", DotDE), func(info *TLDInfo) {
+			info.MaxYears = 1
+			info.RenewGraceDays = 0
+		}),
+		tldWith(ccTLD("es", DotES), func(info *TLDInfo) {
+			info.MaxYears = 5
+			info.ContactAttributes = []string{"es_form_juridica", "es_tipo_identificacion", "es_identificacion"}
+			info.RenewGraceDays = 0
+			info.RestoreDays = 0
+		}),
+		tldWith(ccTLD("eu", DotEU), func(info *TLDInfo) {
+			info.ContactAttributes = []string{"countryofcitizenship"}
+			info.RenewGraceDays = 0
+			info.RestoreDays = 40
+		}),
+		ccTLD("hn", DotHN),
+		ccTLD("com.hn", DotHN3rd),
+		ccTLD("net.hn", DotHN3rd),
+		ccTLD("org.hn", DotHN3rd),
+		ccTLD("in", DotIN),
+		ccTLD("co.in", DotIN3rd),
+		ccTLD("firm.in", DotIN3rd),
+		ccTLD("gen.in", DotIN3rd),
+		ccTLD("ind.in", DotIN3rd),
+		ccTLD("net.in", DotIN3rd),
+		ccTLD("org.in", DotIN3rd),
+		tldWith(ccTLD("me", DotME), func(info *TLDInfo) { info.Privacy = true }),
+		tldWith(ccTLD("co.me", DotME3rd), func(info *TLDInfo) { info.Privacy = true }),
+		tldWith(ccTLD("net.me", DotME3rd), func(info *TLDInfo) { info.Privacy = true }),
+		tldWith(ccTLD("org.me", DotME3rd), func(info *TLDInfo) { info.Privacy = true }),
+		ccTLD("mn", DotMN),
+		tldWith(ccTLD("nl", DotNL), func(info *TLDInfo) {
+			info.MaxYears = 1
+			info.RenewGraceDays = 0
+			info.RestoreDays = 40
+		}),
+		tldWith(ccTLD("co.nz", DotNZ3rd), func(info *TLDInfo) { info.RestoreDays = 90 }),
+		tldWith(ccTLD("net.nz", DotNZ3rd), func(info *TLDInfo) { info.RestoreDays = 90 }),
+		tldWith(ccTLD("org.nz", DotNZ3rd), func(info *TLDInfo) { info.RestoreDays = 90 }),
+		tldWith(ccTLD("com.au", DotAU3rd), func(info *TLDInfo) {
+			info.MinYears = 2
+			info.MaxYears = 2
+			info.ContactAttributes = []string{"id-type", "id", "policyReason"}
+		}),
+		tldWith(ccTLD("net.au", DotAU3rd), func(info *TLDInfo) {
+			info.MinYears = 2
+			info.MaxYears = 2
+			info.ContactAttributes = []string{"id-type", "id", "policyReason"}
+		}),
+		tldWith(ccTLD("pw", DotPW), func(info *TLDInfo) { info.Privacy = true }),
+		tldWith(ccTLD("ru", DotRU), func(info *TLDInfo) {
+			info.MaxYears = 1
+			info.ContactAttributes = []string{"contract-type", "birth-date", "person-r"}
+			info.RestoreDays = 0
+		}),
+		tldWith(ccTLD("com.ru", DotRU3rd), func(info *TLDInfo) { info.MaxYears = 1 }),
+		tldWith(ccTLD("net.ru", DotRU3rd), func(info *TLDInfo) { info.MaxYears = 1 }),
+		tldWith(ccTLD("org.ru", DotRU3rd), func(info *TLDInfo) { info.MaxYears = 1 }),
+		ccTLD("sc", DotSC),
+		ccTLD("sx", DotSX),
+		ccTLD("vc", DotVC),
+		tldWith(ccTLD("ws", DotWS), func(info *TLDInfo) { info.Privacy = true }),
+		tldWith(ccTLD("uk", DotUK), ukPolicy),
+		tldWith(ccTLD("co.uk", DotUK3rd), ukPolicy),
+		tldWith(ccTLD("me.uk", DotUK3rd), ukPolicy),
+		tldWith(ccTLD("org.uk", DotUK3rd), ukPolicy),
+	)
+	return ret
+}
+
+// ukPolicy applies to .uk, whose transfers are a change of registrar tag instead of an auth code.
+func ukPolicy(info *TLDInfo) {
+	info.TransferAuthCode = false
+	info.RenewGraceDays = 0
+	info.RestoreDays = 90
+}
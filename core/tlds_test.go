@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTLDCatalog(t *testing.T) {
+	c := DefaultTLDCatalog()
+
+	com, ok := c.Lookup(".COM")
+	require.True(t, ok)
+	require.Equal(t, DotCOM, com.Key)
+	require.True(t, com.Privacy)
+	require.True(t, com.IDNLanguage)
+
+	info, ok := c.LookupDomain("shop.example.co.uk")
+	require.True(t, ok)
+	require.Equal(t, "co.uk", info.TLD)
+	require.Equal(t, DotUK3rd, info.Key)
+	require.False(t, info.TransferAuthCode)
+
+	info, ok = c.LookupDomain("example.berlin")
+	require.True(t, ok)
+	require.Equal(t, DotBERLIN, info.Key)
+
+	_, ok = c.LookupDomain("example.invalid")
+	require.False(t, ok)
+
+	require.Equal(t, []string{"co.in", "firm.in", "gen.in", "ind.in", "net.in", "org.in"}, DotIN3rd.TLDs())
+	require.Equal(t, []string{"xn--c1avg"}, DotRussianORG.TLDs())
+	require.Empty(t, DotDonutsGroup1.TLDs())
+
+	for _, info := range c.All() {
+		require.NotEmpty(t, info.Key, info.TLD)
+		require.LessOrEqual(t, 1, info.MinYears, info.TLD)
+		require.LessOrEqual(t, info.MinYears, info.MaxYears, info.TLD)
+		require.Contains(t, info.Key.TLDs(), info.TLD)
+	}
+}
+
+func TestNewTLDCatalogOverride(t *testing.T) {
+	ca, _ := DefaultTLDCatalog().Lookup("ca")
+	ca.MaxYears = 2
+	ca.Key = "resellerca"
+	c := NewTLDCatalog(append(DefaultTLDCatalog().All(), ca))
+
+	info, ok := c.Lookup("ca")
+	require.True(t, ok)
+	require.Equal(t, 2, info.MaxYears)
+	require.Equal(t, []string{"ca"}, c.TLDs("resellerca"))
+	require.Empty(t, c.TLDs(DotCA))
+
+	info.ContactAttributes[0] = "changed"
+	info, _ = c.Lookup("ca")
+	require.Equal(t, []string{"CPR"}, info.ContactAttributes)
+
+	info, _ = DefaultTLDCatalog().Lookup("ca")
+	require.Equal(t, 10, info.MaxYears)
+}
@@ -57,11 +57,7 @@ func (c *customer) AuthenticateToken(ctx context.Context, token string, withHist
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	ret := new(Detail)
@@ -102,11 +98,7 @@ func (c *customer) GenerateLoginToken(ctx context.Context, customerID, ip, dashb
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	token := &loginToken{
@@ -143,11 +135,7 @@ func (c *customer) GenerateToken(ctx context.Context, username, password, ip str
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return "", err
-		}
-		return "", errors.New(strings.ToLower(errResponse.Message))
+		return "", core.NewAPIError(resp, bytesResp)
 	}
 
 	return string(bytesResp), nil
@@ -223,12 +211,7 @@ func (c *customer) VerifyOTP(ctx context.Context, customerID, otp string, authTy
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return false, err
-		}
-		return false, errors.New(strings.ToLower(errResponse.Message))
+		return false, core.NewAPIError(resp, bytesResp)
 	}
 
 	return strconv.ParseBool(string(bytesResp))
@@ -251,12 +234,7 @@ func (c *customer) GenerateOTP(ctx context.Context, customerID string) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	boolResult, err := strconv.ParseBool(string(bytesResp))
@@ -298,12 +276,7 @@ func (c *customer) Modify(ctx context.Context, customerIDOrEmail string, modific
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	boolResult, err := strconv.ParseBool(string(bytesResp))
@@ -344,12 +317,7 @@ func (c *customer) Search(ctx context.Context, criteria Criteria, offset, limit
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	replacer := strings.NewReplacer("customer.", "")
@@ -412,12 +380,7 @@ func (c *customer) Suspension(ctx context.Context, toggle bool, customerID, reas
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	boolResult, err := strconv.ParseBool(string(bytesResp))
@@ -448,12 +411,7 @@ func (c *customer) ForgotPassword(ctx context.Context, username string) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	boolResult, err := strconv.ParseBool(string(bytesResp))
@@ -484,12 +442,7 @@ func (c *customer) Delete(ctx context.Context, customerID string) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	boolResult, err := strconv.ParseBool(string(bytesResp))
@@ -531,12 +484,7 @@ func (c *customer) Details(ctx context.Context, customerIDOrEmail string) (*Deta
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	ret := new(Detail)
@@ -568,12 +516,7 @@ func (c *customer) ChangePassword(ctx context.Context, customerID, newPassword s
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	boolResult, err := strconv.ParseBool(string(bytesResp))
@@ -604,12 +547,7 @@ func (c *customer) SignUp(ctx context.Context, regForm *SignUpForm) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return core.NewAPIError(resp, bytesResp)
 	}
 
 	regForm.CustomerID = string(bytesResp)
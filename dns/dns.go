@@ -4,13 +4,11 @@ package dns
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
 
 	"github.com/mrehanabbasi/go-logicboxes/core"
 )
@@ -77,11 +75,7 @@ func (d *dns) ActivatingDNSService(ctx context.Context, orderID string) (*Activa
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result ActivatingDNSServiceResponse
@@ -111,11 +105,7 @@ func (d *dns) AddingIPv4AddressRecord(ctx context.Context, domainName, value, ho
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -145,11 +135,7 @@ func (d *dns) AddingIPv6AddressRecord(ctx context.Context, domainName, value, ho
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -179,11 +165,7 @@ func (d *dns) AddingCNAMERecord(ctx context.Context, domainName, value, host str
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -214,11 +196,7 @@ func (d *dns) AddingMXRecord(ctx context.Context, domainName, value, host string
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -248,11 +226,7 @@ func (d *dns) AddingNSRecord(ctx context.Context, domainName, value, host string
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -271,7 +245,7 @@ func (d *dns) AddingTXTRecord(ctx context.Context, domainName, value, host strin
 	data.Add("host", host)
 	data.Add("ttl", strconv.Itoa(ttl))
 
-	resp, err := d.core.CallAPI(ctx, http.MethodPost, "dns", "manage/manage/add-ns-record", data)
+	resp, err := d.core.CallAPI(ctx, http.MethodPost, "dns", "manage/add-txt-record", data)
 	if err != nil {
 		return nil, err
 	}
@@ -283,11 +257,7 @@ func (d *dns) AddingTXTRecord(ctx context.Context, domainName, value, host strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -320,11 +290,7 @@ func (d *dns) AddingSRVRecord(ctx context.Context, domainName, value, host strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -359,11 +325,7 @@ func (d *dns) ModifyingIPv4AddressRecord(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -398,11 +360,7 @@ func (d *dns) ModifyingIPv6AddressRecord(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -433,11 +391,7 @@ func (d *dns) ModifyingCNAMERecord(ctx context.Context, domainName, host, curren
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -473,11 +427,7 @@ func (d *dns) ModifyingMXRecord(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -508,11 +458,7 @@ func (d *dns) ModifyingNSRecord(ctx context.Context, domainName, host, currentVa
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -543,11 +489,7 @@ func (d *dns) ModifyingTXTRecord(ctx context.Context, domainName, host, currentV
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -585,11 +527,7 @@ func (d *dns) ModifyingSRVRecord(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -625,11 +563,7 @@ func (d *dns) ModifyingSOARecord(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -667,11 +601,7 @@ func (d *dns) SearchingDNSRecords(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var records SearchingDNSRecords
@@ -728,11 +658,7 @@ func (d *dns) DeletingDNSRecord(ctx context.Context, host, value string) (*StdRe
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -761,11 +687,7 @@ func (d *dns) DeletingIPv4AddressRecord(ctx context.Context, domainName, host, v
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -794,11 +716,7 @@ func (d *dns) DeletingIPv6AddressRecord(ctx context.Context, domainName, host, v
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -827,11 +745,7 @@ func (d *dns) DeletingCNAMERecord(ctx context.Context, domainName, host, value s
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -860,11 +774,7 @@ func (d *dns) DeletingMXRecord(ctx context.Context, domainName, host, value stri
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -893,11 +803,7 @@ func (d *dns) DeletingNSRecord(ctx context.Context, domainName, host, value stri
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -926,11 +832,7 @@ func (d *dns) DeletingTXTRecord(ctx context.Context, domainName, host, value str
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -961,11 +863,7 @@ func (d *dns) DeletingSRVRecord(ctx context.Context, domainName, host, value str
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
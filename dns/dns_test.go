@@ -0,0 +1,24 @@
+package dns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/dns"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddingTXTRecord(t *testing.T) {
+	srv := rctest.NewServer()
+	t.Cleanup(srv.Close)
+	z := dns.New(core.New(srv.Config(), srv.Client()))
+
+	_, err := z.AddingTXTRecord(context.Background(), "example.com", "v=spf1 -all", "@", 3600)
+	require.NoError(t, err)
+
+	calls := srv.Calls()
+	require.Equal(t, "dns/manage/add-txt-record", calls[len(calls)-1].Path)
+	require.Equal(t, []rctest.DNSRecord{{Type: "TXT", Host: "@", Value: "v=spf1 -all", TTL: 3600}}, srv.Records("example.com"))
+}
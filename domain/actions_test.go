@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func lastCall(t *testing.T, srv *rctest.Server) rctest.Call {
+	t.Helper()
+	calls := srv.Calls()
+	require.NotEmpty(t, calls)
+	return calls[len(calls)-1]
+}
+
+func TestRenew(t *testing.T) {
+	srv, d := newFake(t)
+	end := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	orderID := srv.AddOrder(rctest.Order{DomainName: "example.com", EndTime: end})
+
+	res, err := d.Renew(context.Background(), orderID, 2, int(end.Unix()), false, true, domain.InvoiceKeep, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, orderID, res.EntityID)
+	require.NotEmpty(t, res.InvoiceID)
+	require.Equal(t, "USD", res.SellingCurrencySymbol)
+
+	order, _ := srv.Order(orderID)
+	require.Equal(t, end.AddDate(2, 0, 0), order.EndTime.UTC())
+	require.Equal(t, "KeepInvoice", lastCall(t, srv).Params.Get("invoice-option"))
+
+	calls := len(srv.Calls())
+	_, err = d.Renew(context.Background(), orderID, 1, 0, false, false, "Later", 0, false)
+	require.ErrorIs(t, err, core.ErrValidation)
+	_, err = d.Renew(context.Background(), orderID, 0, 0, false, false, domain.InvoicePay, 0, false)
+	require.ErrorIs(t, err, core.ErrValidation)
+	require.Len(t, srv.Calls(), calls)
+}
+
+func TestRestore(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{DomainName: "example.com", Status: "Pending Delete Restorable"})
+
+	res, err := d.Restore(context.Background(), orderID, domain.InvoicePay)
+	require.NoError(t, err)
+	require.Equal(t, "RestoreDomain", res.ActionType)
+	require.NotEmpty(t, res.InvoiceID)
+
+	order, _ := srv.Order(orderID)
+	require.Equal(t, "Active", order.Status)
+
+	_, err = d.Restore(context.Background(), orderID, domain.InvoicePay)
+	var apiErr *core.APIError
+	require.ErrorAs(t, err, &apiErr)
+}
+
+func TestResendTransferApprovalMail(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{
+		DomainName:   "example.com",
+		Status:       "InActive",
+		DomainStatus: []string{"pendingtransfer"},
+	})
+
+	res, err := d.ResendTransferApprovalMail(context.Background(), orderID)
+	require.NoError(t, err)
+	require.Equal(t, "ResendRFA", res.ActionType)
+
+	srv.Handle("domains/resend-rfa", func(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+		rctest.WriteText(w, true)
+	})
+	res, err = d.ResendTransferApprovalMail(context.Background(), orderID)
+	require.NoError(t, err)
+	require.Equal(t, "Success", res.Status)
+}
+
+func TestRegistrySpecificActions(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{DomainName: "example.tel"})
+	ctx := context.Background()
+
+	res, err := d.ModifyTELWhoisPreference(ctx, orderID, domain.TELWhoisNatural, false)
+	require.NoError(t, err)
+	require.Equal(t, "Success", res.ActionStatus)
+	params := lastCall(t, srv).Params
+	require.Equal(t, "Natural", params.Get("whois-type"))
+	require.Equal(t, "n", params.Get("publish"))
+
+	_, err = d.ModifyTELWhoisPreference(ctx, orderID, domain.TELWhoisLegal, false)
+	require.ErrorIs(t, err, core.ErrValidation)
+	_, err = d.ModifyTELWhoisPreference(ctx, orderID, "Robot", true)
+	require.ErrorIs(t, err, core.ErrValidation)
+
+	_, err = d.ReleaseUKDomainName(ctx, orderID, "NEWTAG")
+	require.NoError(t, err)
+	require.Equal(t, "NEWTAG", lastCall(t, srv).Params.Get("new-tag"))
+
+	_, err = d.RecheckingNSWithDERegistry(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, "domains/de/recheck-ns", lastCall(t, srv).Path)
+
+	_, err = d.AssociatingOrDissociatingXXXMembershipTokenID(ctx, orderID, "token")
+	require.NoError(t, err)
+	require.Equal(t, "token", lastCall(t, srv).Params.Get("association-id"))
+
+	_, err = d.RecheckingNSWithDERegistry(ctx, "0")
+	require.ErrorIs(t, err, core.ErrNotFound)
+}
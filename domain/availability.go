@@ -5,6 +5,25 @@ import "github.com/mrehanabbasi/go-logicboxes/core"
 type Registration struct {
 	Key    core.DomainKey     `json:"classkey"`
 	Status RegistrationStatus `json:"status"`
+	// Premium is set by BulkCheck with BulkCheckOptions.Premium for available names. It is nil when
+	// the premium status is unknown, in which case the standard price must not be assumed.
+	Premium *PremiumInfo `json:"-"`
+}
+
+// IsPremium reports whether the name is known to be premium.
+func (r Registration) IsPremium() bool {
+	return r.Premium != nil && r.Premium.IsPremium.ToBool()
 }
 
 type Availabilities map[string]Registration
+
+// PremiumInfo is the premium status of a domain name. Prices are only set for premium names, in the
+// reseller's selling currency.
+type PremiumInfo struct {
+	IsPremium    core.JSONBool  `json:"premium"`
+	CostPrice    core.JSONFloat `json:"cost_price,omitempty"`
+	SellingPrice core.JSONFloat `json:"selling_price,omitempty"`
+	Currency     string         `json:"currency,omitempty"`
+}
+
+type PremiumAvailabilities map[string]PremiumInfo
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultBulkBatchSize is the default number of name and TLD combinations sent per availability request.
+	DefaultBulkBatchSize = 100
+	// DefaultBulkConcurrency is the default number of availability requests in flight.
+	DefaultBulkConcurrency = 4
+)
+
+// BulkCheckOptions tunes BulkCheck. Zero values use the defaults.
+type BulkCheckOptions struct {
+	// BatchSize caps the number of name and TLD combinations of a single request.
+	BatchSize int
+	// Concurrency is the number of requests in flight. The client's own rate and concurrency
+	// limits still apply.
+	Concurrency int
+	// Premium additionally checks the premium status of every available name, see Registration.Premium.
+	Premium bool
+}
+
+// BatchError is the failure of one request of a BulkCheck. Labels and TLDs are set for availability
+// requests, DomainNames for premium checks.
+type BatchError struct {
+	Labels      []string
+	TLDs        []string
+	DomainNames []string
+	Err         error
+}
+
+func (e *BatchError) Error() string {
+	if len(e.DomainNames) > 0 {
+		return fmt.Sprintf("checking premium status of %d names: %v", len(e.DomainNames), e.Err)
+	}
+	return fmt.Sprintf("checking %d names across %d tlds: %v", len(e.Labels), len(e.TLDs), e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// BulkCheckResult holds the merged availabilities of the successful batches and the failed ones.
+type BulkCheckResult struct {
+	Availabilities Availabilities
+	Failures       []*BatchError
+}
+
+// Err joins the batch failures, or returns nil when every batch succeeded.
+func (r *BulkCheckResult) Err() error {
+	errs := make([]error, len(r.Failures))
+	for i, f := range r.Failures {
+		errs[i] = f
+	}
+	return errors.Join(errs...)
+}
+
+type availabilityBatch struct {
+	labels []string
+	tlds   []string
+}
+
+// BulkCheck checks the availability of every label under every TLD. The combinations are split into
+// batches sent concurrently, and their results are merged. A failed batch does not discard the others:
+// the result is always returned, along with Err() of the result when some batches failed.
+func BulkCheck(ctx context.Context, d Domain, labels, tlds []string, opts BulkCheckOptions) (*BulkCheckResult, error) {
+	labels, tlds = normalizeLabels(labels), normalizeTLDs(tlds)
+	if len(labels) == 0 || len(tlds) == 0 {
+		return nil, errors.New("labels and tlds must not empty")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBulkBatchSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultBulkConcurrency
+	}
+
+	result := &BulkCheckResult{Availabilities: Availabilities{}}
+	mu := sync.Mutex{}
+
+	forEachConcurrently(splitBatches(labels, tlds, opts.BatchSize), opts.Concurrency, func(b availabilityBatch) {
+		availabilities, err := checkBatch(ctx, d, b)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.Failures = append(result.Failures, &BatchError{Labels: b.labels, TLDs: b.tlds, Err: err})
+		}
+		for name, registration := range availabilities {
+			result.Availabilities[name] = registration
+		}
+	})
+
+	if opts.Premium {
+		var available []string
+		for name, registration := range result.Availabilities {
+			if registration.Status == DomRegUnregistered {
+				available = append(available, name)
+			}
+		}
+		slices.Sort(available)
+
+		forEachConcurrently(chunk(available, opts.BatchSize), opts.Concurrency, func(names []string) {
+			premiums, err := checkPremiumBatch(ctx, d, names)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failures = append(result.Failures, &BatchError{DomainNames: names, Err: err})
+				return
+			}
+			for name, info := range premiums {
+				if registration, ok := result.Availabilities[name]; ok {
+					registration.Premium = &info
+					result.Availabilities[name] = registration
+				}
+			}
+		})
+	}
+
+	return result, result.Err()
+}
+
+func checkBatch(ctx context.Context, d Domain, b availabilityBatch) (Availabilities, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return d.CheckAvailability(ctx, b.labels, b.tlds)
+}
+
+func checkPremiumBatch(ctx context.Context, d Domain, names []string) (PremiumAvailabilities, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return d.PremiumCheck(ctx, names)
+}
+
+// forEachConcurrently calls fn for every item from at most concurrency goroutines.
+func forEachConcurrently[T any](items []T, concurrency int, fn func(T)) {
+	queue := make(chan T)
+	go func() {
+		defer close(queue)
+		for _, item := range items {
+			queue <- item
+		}
+	}()
+
+	wg := sync.WaitGroup{}
+	for range min(concurrency, len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				fn(item)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func chunk(values []string, size int) [][]string {
+	var ret [][]string
+	for i := 0; i < len(values); i += size {
+		ret = append(ret, values[i:min(i+size, len(values))])
+	}
+	return ret
+}
+
+// splitBatches splits the labels × tlds product into batches of at most size combinations.
+func splitBatches(labels, tlds []string, size int) []availabilityBatch {
+	tldsPerBatch := min(len(tlds), size)
+	labelsPerBatch := max(size/tldsPerBatch, 1)
+
+	var ret []availabilityBatch
+	for t := 0; t < len(tlds); t += tldsPerBatch {
+		tldChunk := tlds[t:min(t+tldsPerBatch, len(tlds))]
+		for l := 0; l < len(labels); l += labelsPerBatch {
+			ret = append(ret, availabilityBatch{
+				labels: labels[l:min(l+labelsPerBatch, len(labels))],
+				tlds:   tldChunk,
+			})
+		}
+	}
+	return ret
+}
+
+func normalizeLabels(labels []string) []string {
+	return dedup(labels, func(s string) string {
+		return strings.ToLower(strings.TrimSpace(s))
+	})
+}
+
+func normalizeTLDs(tlds []string) []string {
+	return dedup(tlds, func(s string) string {
+		return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), ".")
+	})
+}
+
+func dedup(values []string, normalize func(string) string) []string {
+	seen := make(map[string]bool, len(values))
+	ret := make([]string, 0, len(values))
+	for _, v := range values {
+		v = normalize(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		ret = append(ret, v)
+	}
+	return ret
+}
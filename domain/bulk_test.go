@@ -0,0 +1,152 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func labels(n int) []string {
+	ret := make([]string, n)
+	for i := range ret {
+		ret[i] = fmt.Sprintf("brand%03d", i)
+	}
+	return ret
+}
+
+func TestBulkCheck(t *testing.T) {
+	srv, d := newFake(t)
+	srv.SetTaken("brand007.net")
+	srv.AddOrder(rctest.Order{DomainName: "brand001.com"})
+
+	res, err := domain.BulkCheck(context.Background(), d, labels(250), []string{"com", ".NET", "org", "com"},
+		domain.BulkCheckOptions{BatchSize: 100})
+	require.NoError(t, err)
+	require.Empty(t, res.Failures)
+	require.Len(t, res.Availabilities, 750)
+	require.Equal(t, domain.DomRegThroughUs, res.Availabilities["brand001.com"].Status)
+	require.Equal(t, domain.DomRegThroughOthers, res.Availabilities["brand007.net"].Status)
+	require.Equal(t, domain.DomRegUnregistered, res.Availabilities["brand249.org"].Status)
+
+	calls := srv.Calls()
+	require.Len(t, calls, 8)
+	for _, call := range calls {
+		require.LessOrEqual(t, len(call.Params["domain-name"])*len(call.Params["tlds"]), 100)
+	}
+}
+
+func TestBulkCheckManyTLDs(t *testing.T) {
+	srv, d := newFake(t)
+	tlds := make([]string, 60)
+	for i := range tlds {
+		tlds[i] = fmt.Sprintf("tld%d", i)
+	}
+
+	res, err := domain.BulkCheck(context.Background(), d, labels(5), tlds, domain.BulkCheckOptions{BatchSize: 50})
+	require.NoError(t, err)
+	require.Len(t, res.Availabilities, 300)
+	require.Len(t, srv.Calls(), 10)
+}
+
+func TestBulkCheckPartialFailure(t *testing.T) {
+	srv, d := newFake(t)
+	var inFlight, peak atomic.Int32
+	srv.Handle("domains/available", func(w http.ResponseWriter, _ *http.Request, params url.Values) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		if n > peak.Load() {
+			peak.Store(n)
+		}
+		if slices.Contains(params["domain-name"], "brand042") {
+			rctest.WriteError(w, http.StatusInternalServerError, "Invalid domain-name")
+			return
+		}
+		ret := map[string]map[string]string{}
+		for _, name := range params["domain-name"] {
+			for _, tld := range params["tlds"] {
+				ret[name+"."+tld] = map[string]string{"classkey": "domcno", "status": "available"}
+			}
+		}
+		rctest.WriteJSON(w, ret)
+	})
+
+	res, err := domain.BulkCheck(context.Background(), d, labels(100), []string{"com"},
+		domain.BulkCheckOptions{BatchSize: 10, Concurrency: 3})
+	require.Error(t, err)
+	require.Len(t, res.Failures, 1)
+	require.Contains(t, res.Failures[0].Labels, "brand042")
+	require.Len(t, res.Availabilities, 90)
+	require.LessOrEqual(t, peak.Load(), int32(3))
+
+	_, err = domain.BulkCheck(context.Background(), d, nil, []string{"com"}, domain.BulkCheckOptions{})
+	require.Error(t, err)
+}
+
+func TestBulkCheckCanceled(t *testing.T) {
+	srv, d := newFake(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res, err := domain.BulkCheck(ctx, d, labels(30), []string{"com"}, domain.BulkCheckOptions{BatchSize: 10})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, res.Failures, 3)
+	require.Empty(t, srv.Calls())
+}
+
+func TestPremiumCheck(t *testing.T) {
+	srv, d := newFake(t)
+	srv.SetPremium("gold.com", 2500)
+
+	res, err := d.PremiumCheck(context.Background(), []string{"gold.com", "plain.com"})
+	require.NoError(t, err)
+	require.True(t, res["gold.com"].IsPremium.ToBool())
+	require.InDelta(t, 2500, res["gold.com"].SellingPrice.ToFloat64(), 0.001)
+	require.InDelta(t, 2000, res["gold.com"].CostPrice.ToFloat64(), 0.001)
+	require.Equal(t, "USD", res["gold.com"].Currency)
+	require.False(t, res["plain.com"].IsPremium.ToBool())
+}
+
+func TestBulkCheckPremium(t *testing.T) {
+	srv, d := newFake(t)
+	srv.SetPremium("brand003.net", 900)
+	srv.SetTaken("brand004.com")
+
+	res, err := domain.BulkCheck(context.Background(), d, labels(10), []string{"com", "net"},
+		domain.BulkCheckOptions{BatchSize: 6, Premium: true})
+	require.NoError(t, err)
+	require.Len(t, res.Availabilities, 20)
+
+	premium := res.Availabilities["brand003.net"]
+	require.True(t, premium.IsPremium())
+	require.InDelta(t, 900, premium.Premium.SellingPrice.ToFloat64(), 0.001)
+
+	standard := res.Availabilities["brand003.com"]
+	require.NotNil(t, standard.Premium)
+	require.False(t, standard.IsPremium())
+	require.Nil(t, res.Availabilities["brand004.com"].Premium)
+}
+
+func TestBulkCheckPremiumFailure(t *testing.T) {
+	srv, d := newFake(t)
+	srv.Handle("domains/premium-check", func(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+		rctest.WriteError(w, http.StatusServiceUnavailable, "premium service unavailable")
+	})
+
+	res, err := domain.BulkCheck(context.Background(), d, labels(3), []string{"com"},
+		domain.BulkCheckOptions{Premium: true})
+	require.Error(t, err)
+	require.Len(t, res.Failures, 1)
+	require.Len(t, res.Failures[0].DomainNames, 3)
+	require.Len(t, res.Availabilities, 3)
+	for _, registration := range res.Availabilities {
+		require.Nil(t, registration.Premium)
+	}
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// DetailOption selects the fields returned by GetRegistrationOrderDetails.
+type DetailOption string
+
+// Const for order details options.
+const (
+	DetailAll DetailOption = "All"
+	// DetailOrderDetails returns the status, times, auto-renew, privacy and deletion fields.
+	DetailOrderDetails DetailOption = "OrderDetails"
+	// DetailContactIDs returns the contact IDs, the contact details options their details.
+	DetailContactIDs        DetailOption = "ContactIds"
+	DetailRegistrantContact DetailOption = "RegistrantContactDetails"
+	DetailAdminContact      DetailOption = "AdminContactDetails"
+	DetailTechContact       DetailOption = "TechContactDetails"
+	DetailBillingContact    DetailOption = "BillingContactDetails"
+	DetailNameServers       DetailOption = "NsDetails"
+	DetailChildNameServers  DetailOption = "ChildNameServers"
+	DetailDomainStatus      DetailOption = "DomainStatus"
+	DetailDNSSEC            DetailOption = "DNSSECDetails"
+	DetailStatus            DetailOption = "StatusDetails"
+)
+
+var detailOptions = []DetailOption{
+	DetailAll, DetailOrderDetails, DetailContactIDs, DetailRegistrantContact, DetailAdminContact, DetailTechContact,
+	DetailBillingContact, DetailNameServers, DetailChildNameServers, DetailDomainStatus, DetailDNSSEC, DetailStatus,
+}
+
+func (o DetailOption) Validate() error {
+	if !slices.Contains(detailOptions, o) {
+		return fmt.Errorf("%w: unknown details option %q", core.ErrValidation, o)
+	}
+	return nil
+}
+
+// RAAVerificationStatus is the state of the registrant email verification required by the ICANN
+// Registrar Accreditation Agreement.
+type RAAVerificationStatus string
+
+// Const for RAA verification statuses.
+const (
+	RAAVerified      RAAVerificationStatus = "Verified"
+	RAAPending       RAAVerificationStatus = "Pending"
+	RAASuspended     RAAVerificationStatus = "Suspended"
+	RAANotApplicable RAAVerificationStatus = "NA"
+)
+
+type GDPRStatus struct {
+	Enabled  core.JSONBool `json:"enabled"`
+	Eligible core.JSONBool `json:"eligible"`
+}
+
+// ChildNameServers maps the child name servers of a domain to their IP addresses.
+type ChildNameServers map[string][]string
+
+// UnmarshalJSON accepts the empty array and string the API sends when there are none, and a
+// single IP address as a string.
+func (c *ChildNameServers) UnmarshalJSON(b []byte) error {
+	var hosts map[string]core.JSONStrings
+	if err := json.Unmarshal(b, &hosts); err != nil {
+		var empty core.JSONStrings
+		if json.Unmarshal(b, &empty) != nil || len(empty) > 0 {
+			return err
+		}
+	}
+
+	*c = make(ChildNameServers, len(hosts))
+	for host, ips := range hosts {
+		(*c)[host] = ips
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the details and collects every nsN field, the API returns up to 13. Numbers
+// and booleans are decoded as strings since the API sends either for many fields.
+func (o *OrderDetail) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var fields map[string]any
+	if err := dec.Decode(&fields); err != nil {
+		return err
+	}
+	normalized, err := json.Marshal(stringifyScalars(fields))
+	if err != nil {
+		return err
+	}
+
+	type orderDetail OrderDetail
+	*o = OrderDetail{}
+	if err := json.Unmarshal(normalized, (*orderDetail)(o)); err != nil {
+		return err
+	}
+
+	byIndex := map[int]string{}
+	for key, value := range fields {
+		index, err := strconv.Atoi(strings.TrimPrefix(key, "ns"))
+		if ns, ok := value.(string); ok && ns != "" && strings.HasPrefix(key, "ns") && err == nil {
+			byIndex[index] = ns
+		}
+	}
+	for _, index := range slices.Sorted(maps.Keys(byIndex)) {
+		o.nameServers = append(o.nameServers, byIndex[index])
+	}
+	return nil
+}
+
+func stringifyScalars(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = stringifyScalars(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = stringifyScalars(value)
+		}
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return v
+}
+
+// NameServers returns the name servers of the NsDetails option, in order.
+func (o *OrderDetail) NameServers() []string {
+	if o.nameServers != nil {
+		return slices.Clone(o.nameServers)
+	}
+	var ret []string
+	for _, ns := range []string{o.NS1, o.NS2, o.NS3, o.NS4, o.NS5, o.NS6} {
+		if ns != "" {
+			ret = append(ret, ns)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,188 @@
+package domain_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+// detailChecks verifies the fields of each fixture in testdata/details.
+var detailChecks = map[domain.DetailOption]func(t *testing.T, detail *domain.OrderDetail){
+	domain.DetailOrderDetails: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, "562994", detail.OrderID)
+		require.Equal(t, "3", detail.EntityTypeID)
+		require.Equal(t, "Active", detail.CurrentStatus)
+		require.Equal(t, core.JSONStrings{"transferlock"}, detail.OrderStatus)
+		require.Equal(t, time.Unix(1541751018, 0), detail.CreationTime.ToTime())
+		require.Equal(t, time.Unix(1699517418, 0), detail.EndTime.ToTime())
+		require.False(t, detail.AllowDeletion.ToBool())
+		require.True(t, detail.Recurring.ToBool())
+		require.False(t, detail.IsPrivacyProtected.ToBool())
+		require.True(t, detail.PrivacyProtectedAllowed.ToBool())
+		require.False(t, detail.TNCRequired.ToBool())
+		require.False(t, detail.PremiumDNSEnabled.ToBool())
+		require.Equal(t, uint16(30), detail.AutoRenewAttemptDuration.ToUint16())
+		require.InDelta(t, 9.99, detail.CustomerCost.ToFloat64(), 0.001)
+		require.InDelta(t, 7.5, detail.ResellerCost.ToFloat64(), 0.001)
+		require.Equal(t, domain.RAAPending, detail.RaaVerificationStatus)
+		require.Equal(t, time.Unix(1541751019, 0), detail.RaaVerificationStartTime.ToTime())
+		require.True(t, detail.GDPR.Enabled.ToBool())
+		require.True(t, detail.GDPR.Eligible.ToBool())
+		require.Empty(t, detail.JumpConditions)
+	},
+	domain.DetailContactIDs: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, "52031", detail.RegistrantContactID)
+		require.Equal(t, "52032", detail.AdminContactID)
+		require.Equal(t, "52032", detail.TechContactID)
+		require.Equal(t, "-1", detail.BillingContactID)
+	},
+	domain.DetailRegistrantContact: func(t *testing.T, detail *domain.OrderDetail) {
+		c := detail.RegistrantContact
+		require.Equal(t, "52031", c.ContactID)
+		require.Equal(t, core.JSONStrings{"domcno"}, c.ContactType)
+		require.Equal(t, "Suite 100", c.Address2)
+		require.Equal(t, "10001", c.ZIP)
+		require.Equal(t, "1", c.TelnoCC)
+		require.Equal(t, "5551234", c.Telno)
+	},
+	domain.DetailAdminContact: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, "John Doe", detail.Admincontact.Name)
+		require.Empty(t, detail.Admincontact.ContactType)
+	},
+	domain.DetailTechContact: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, core.JSONStrings{"domcno", "dotasia"}, detail.TechContact.ContactType)
+	},
+	domain.DetailBillingContact: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, domain.Contact{}, detail.BillingContact)
+	},
+	domain.DetailNameServers: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, uint16(10), detail.NoOfNameServers.ToUint16())
+		require.Equal(t, []string{
+			"ns1.example.com", "ns2.example.com", "ns3.provider.net", "ns4.provider.net", "ns5.provider.net",
+			"ns6.provider.net", "ns7.provider.net", "ns8.provider.net", "ns9.provider.net", "ns10.provider.net",
+		}, detail.NameServers())
+		require.Equal(t, "ns1.example.com", detail.NS1)
+	},
+	domain.DetailChildNameServers: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, domain.ChildNameServers{
+			"ns1.example.com": {"192.0.2.1", "2001:db8::1"},
+			"ns2.example.com": {"192.0.2.2"},
+		}, detail.CNS)
+	},
+	domain.DetailDomainStatus: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, core.JSONStrings{"sixtydaylock", "clientTransferProhibited"}, detail.DomainStatus)
+	},
+	domain.DetailStatus: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Equal(t, core.JSONStrings{"transferlock"}, detail.OrderStatus)
+		require.Equal(t, core.JSONStrings{"sixtydaylock", "clientTransferProhibited"}, detail.DomainStatus)
+	},
+	domain.DetailDNSSEC: func(t *testing.T, detail *domain.OrderDetail) {
+		require.Len(t, detail.DNSSec, 1)
+		require.Equal(t, uint16(2371), detail.DNSSec[0].KeyTag)
+		require.Equal(t, domain.AlgECDSAP256SHA256, detail.DNSSec[0].Algorithm)
+		require.Equal(t, domain.DigestSHA256, detail.DNSSec[0].DigestType)
+	},
+}
+
+// serveDetailFixtures answers domains/details with the merged fixtures of the requested options.
+func serveDetailFixtures(t *testing.T, srv *rctest.Server) {
+	t.Helper()
+	fixtures := map[string]map[string]any{}
+	for option := range detailChecks {
+		b, err := os.ReadFile(filepath.Join("testdata", "details", string(option)+".json"))
+		require.NoError(t, err)
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal(b, &fields))
+		fixtures[string(option)] = fields
+	}
+
+	srv.Handle("domains/details", func(w http.ResponseWriter, _ *http.Request, params url.Values) {
+		merged := map[string]any{}
+		for option, fields := range fixtures {
+			if params.Has("options") && (params.Get("options") == "All" || slices.Contains(params["options"], option)) {
+				for key, value := range fields {
+					merged[key] = value
+				}
+			}
+		}
+		rctest.WriteJSON(w, merged)
+	})
+}
+
+func TestGetRegistrationOrderDetailsOptions(t *testing.T) {
+	srv, d := newFake(t)
+	serveDetailFixtures(t, srv)
+	ctx := context.Background()
+
+	for option, check := range detailChecks {
+		t.Run(string(option), func(t *testing.T) {
+			detail, err := d.GetRegistrationOrderDetails(ctx, "562994", []domain.DetailOption{option})
+			require.NoError(t, err)
+			require.Equal(t, "example.com", detail.DomainName)
+			check(t, detail)
+			require.Equal(t, []string{string(option)}, lastCall(t, srv).Params["options"])
+		})
+	}
+
+	for _, options := range [][]domain.DetailOption{
+		{domain.DetailOrderDetails, domain.DetailNameServers, domain.DetailChildNameServers},
+		{domain.DetailContactIDs, domain.DetailRegistrantContact, domain.DetailAdminContact, domain.DetailTechContact},
+		{domain.DetailOrderDetails, domain.DetailDomainStatus, domain.DetailDNSSEC},
+	} {
+		detail, err := d.GetRegistrationOrderDetails(ctx, "562994", options)
+		require.NoError(t, err)
+		for _, option := range options {
+			detailChecks[option](t, detail)
+		}
+	}
+
+	detail, err := d.GetRegistrationOrderDetails(ctx, "562994", []domain.DetailOption{domain.DetailAll})
+	require.NoError(t, err)
+	for _, check := range detailChecks {
+		check(t, detail)
+	}
+}
+
+func TestGetRegistrationOrderDetailsInvalidOptions(t *testing.T) {
+	srv, d := newFake(t)
+	for _, options := range [][]domain.DetailOption{nil, {"NSDetails"}} {
+		_, err := d.GetRegistrationOrderDetails(context.Background(), "1", options)
+		require.ErrorIs(t, err, core.ErrValidation)
+	}
+	require.Empty(t, srv.Calls())
+}
+
+func TestOrderDetailTolerantDecoding(t *testing.T) {
+	var detail domain.OrderDetail
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"cns": [],
+		"domainstatus": "",
+		"orderstatus": null,
+		"allowdeletion": "No",
+		"recurring": "y",
+		"endtime": "0",
+		"customercost": ""
+	}`), &detail))
+	require.Empty(t, detail.CNS)
+	require.Empty(t, detail.DomainStatus)
+	require.Empty(t, detail.OrderStatus)
+	require.False(t, detail.AllowDeletion.ToBool())
+	require.True(t, detail.Recurring.ToBool())
+	require.True(t, detail.EndTime.ToTime().IsZero())
+	require.Zero(t, detail.CustomerCost.ToFloat64())
+	require.Nil(t, detail.NameServers())
+
+	require.Error(t, json.Unmarshal([]byte(`{"cns": ["192.0.2.1"]}`), &detail))
+	require.Error(t, json.Unmarshal([]byte(`{"recurring": "sometimes"}`), &detail))
+}
@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // SHA-1 is DS digest type 1
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// DNSSECAlgorithm is a DNSKEY algorithm number.
+type DNSSECAlgorithm uint8
+
+// Const for the DNSSEC algorithms accepted in DS records.
+const (
+	AlgRSASHA1         DNSSECAlgorithm = 5
+	AlgRSASHA1NSEC3    DNSSECAlgorithm = 7
+	AlgRSASHA256       DNSSECAlgorithm = 8
+	AlgRSASHA512       DNSSECAlgorithm = 10
+	AlgECDSAP256SHA256 DNSSECAlgorithm = 13
+	AlgECDSAP384SHA384 DNSSECAlgorithm = 14
+	AlgED25519         DNSSECAlgorithm = 15
+	AlgED448           DNSSECAlgorithm = 16
+)
+
+// DigestType is a DS digest type number.
+type DigestType uint8
+
+// Const for DS digest types.
+const (
+	DigestSHA1   DigestType = 1
+	DigestSHA256 DigestType = 2
+	DigestSHA384 DigestType = 4
+)
+
+var digestLengths = map[DigestType]int{
+	DigestSHA1:   sha1.Size,
+	DigestSHA256: sha256.Size,
+	DigestSHA384: sha512.Size384,
+}
+
+// DSRecord is a delegation signer record published at the registry.
+type DSRecord struct {
+	KeyTag     uint16
+	Algorithm  DNSSECAlgorithm
+	DigestType DigestType
+	// Digest is the hex encoded digest, in upper case.
+	Digest string
+}
+
+// ParseDSRecord parses the presentation format of a DS record's data, "<key tag> <algorithm>
+// <digest type> <digest>". The digest may be split by spaces.
+func ParseDSRecord(s string) (DSRecord, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 4 {
+		return DSRecord{}, fmt.Errorf("%w: ds record %q: expected 4 fields", core.ErrValidation, s)
+	}
+	return newDSRecord(fields[0], fields[1], fields[2], strings.Join(fields[3:], ""))
+}
+
+func newDSRecord(keyTag, algorithm, digestType, digest string) (DSRecord, error) {
+	tag, err := strconv.ParseUint(keyTag, 10, 16)
+	if err != nil {
+		return DSRecord{}, fmt.Errorf("%w: ds key tag %q", core.ErrValidation, keyTag)
+	}
+	alg, err := strconv.ParseUint(algorithm, 10, 8)
+	if err != nil {
+		return DSRecord{}, fmt.Errorf("%w: ds algorithm %q", core.ErrValidation, algorithm)
+	}
+	dt, err := strconv.ParseUint(digestType, 10, 8)
+	if err != nil {
+		return DSRecord{}, fmt.Errorf("%w: ds digest type %q", core.ErrValidation, digestType)
+	}
+	return DSRecord{
+		KeyTag:     uint16(tag),
+		Algorithm:  DNSSECAlgorithm(alg),
+		DigestType: DigestType(dt),
+		Digest:     strings.ToUpper(digest),
+	}, nil
+}
+
+// String returns the presentation format of the record's data.
+func (r DSRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.KeyTag, r.Algorithm, r.DigestType, r.Digest)
+}
+
+// Validate checks the algorithm, the digest type and the digest length. SHA-1 digests are only
+// accepted for the RSA algorithms, the later algorithms require SHA-256 or SHA-384.
+func (r DSRecord) Validate() error {
+	switch r.Algorithm {
+	case AlgRSASHA1, AlgRSASHA1NSEC3, AlgRSASHA256, AlgRSASHA512:
+	case AlgECDSAP256SHA256, AlgECDSAP384SHA384, AlgED25519, AlgED448:
+		if r.DigestType == DigestSHA1 {
+			return fmt.Errorf("%w: ds record %s: sha-1 digest with algorithm %d", core.ErrValidation, r, r.Algorithm)
+		}
+	default:
+		return fmt.Errorf("%w: ds record %s: unsupported algorithm %d", core.ErrValidation, r, r.Algorithm)
+	}
+
+	length, ok := digestLengths[r.DigestType]
+	if !ok {
+		return fmt.Errorf("%w: ds record %s: unsupported digest type %d", core.ErrValidation, r, r.DigestType)
+	}
+	digest, err := hex.DecodeString(r.Digest)
+	if err != nil || len(digest) != length {
+		return fmt.Errorf("%w: ds record %s: digest must be %d hex encoded bytes", core.ErrValidation, r, length)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the record like the API, as an object of strings.
+func (r DSRecord) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"keytag":     strconv.Itoa(int(r.KeyTag)),
+		"algorithm":  strconv.Itoa(int(r.Algorithm)),
+		"digesttype": strconv.Itoa(int(r.DigestType)),
+		"digest":     r.Digest,
+	})
+}
+
+// UnmarshalJSON accepts the object form, with string or numeric values, and the presentation
+// format string.
+func (r *DSRecord) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		record, err := ParseDSRecord(s)
+		if err != nil {
+			return err
+		}
+		*r = record
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	get := func(key string) string {
+		return strings.Trim(string(fields[key]), `"`)
+	}
+	record, err := newDSRecord(get("keytag"), get("algorithm"), get("digesttype"), get("digest"))
+	if err != nil {
+		return err
+	}
+	*r = record
+	return nil
+}
+
+// dnssecValues validates the records and returns them as the attributes of add-dnssec and del-dnssec.
+func dnssecValues(orderID string, records []DSRecord) (url.Values, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: records must not be empty", core.ErrValidation)
+	}
+
+	data := url.Values{"order-id": {orderID}}
+	i := 0
+	for _, r := range records {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		for _, attr := range [][2]string{
+			{"keytag", strconv.Itoa(int(r.KeyTag))},
+			{"algorithm", strconv.Itoa(int(r.Algorithm))},
+			{"digesttype", strconv.Itoa(int(r.DigestType))},
+			{"digest", r.Digest},
+		} {
+			i++
+			data["attr-name"+strconv.Itoa(i)] = []string{attr[0]}
+			data["attr-value"+strconv.Itoa(i)] = []string{attr[1]}
+		}
+	}
+	return data, nil
+}
+
+// DNSKEY is the data of a DNSKEY record.
+type DNSKEY struct {
+	Flags     uint16
+	Protocol  uint8
+	Algorithm DNSSECAlgorithm
+	PublicKey []byte
+}
+
+// ParseDNSKEY parses the presentation format of a DNSKEY record's data, "<flags> <protocol>
+// <algorithm> <base64 public key>". The key may be split by spaces.
+func ParseDNSKEY(s string) (DNSKEY, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 4 {
+		return DNSKEY{}, fmt.Errorf("%w: dnskey %q: expected 4 fields", core.ErrValidation, s)
+	}
+	flags, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return DNSKEY{}, fmt.Errorf("%w: dnskey flags %q", core.ErrValidation, fields[0])
+	}
+	protocol, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil {
+		return DNSKEY{}, fmt.Errorf("%w: dnskey protocol %q", core.ErrValidation, fields[1])
+	}
+	algorithm, err := strconv.ParseUint(fields[2], 10, 8)
+	if err != nil {
+		return DNSKEY{}, fmt.Errorf("%w: dnskey algorithm %q", core.ErrValidation, fields[2])
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(strings.Join(fields[3:], ""))
+	if err != nil {
+		return DNSKEY{}, fmt.Errorf("%w: dnskey public key: %w", core.ErrValidation, err)
+	}
+	return DNSKEY{
+		Flags:     uint16(flags),
+		Protocol:  uint8(protocol),
+		Algorithm: DNSSECAlgorithm(algorithm),
+		PublicKey: publicKey,
+	}, nil
+}
+
+// rdata returns the wire format of the record's data.
+func (k DNSKEY) rdata() []byte {
+	ret := binary.BigEndian.AppendUint16(nil, k.Flags)
+	ret = append(ret, k.Protocol, byte(k.Algorithm))
+	return append(ret, k.PublicKey...)
+}
+
+// KeyTag computes the key tag of RFC 4034, appendix B.
+func (k DNSKEY) KeyTag() uint16 {
+	var sum uint32
+	for i, b := range k.rdata() {
+		if i%2 == 0 {
+			sum += uint32(b) << 8
+		} else {
+			sum += uint32(b)
+		}
+	}
+	sum += sum >> 16 & 0xffff
+	return uint16(sum)
+}
+
+// ComputeDS computes the DS record of a zone's DNSKEY. The key must have the zone key flag set and
+// use protocol 3.
+func ComputeDS(zone string, key DNSKEY, digestType DigestType) (DSRecord, error) {
+	if key.Flags&0x0100 == 0 {
+		return DSRecord{}, fmt.Errorf("%w: dnskey is not a zone key", core.ErrValidation)
+	}
+	if key.Protocol != 3 {
+		return DSRecord{}, fmt.Errorf("%w: dnskey protocol must be 3", core.ErrValidation)
+	}
+	owner, err := canonicalName(zone)
+	if err != nil {
+		return DSRecord{}, err
+	}
+
+	data := append(owner, key.rdata()...)
+	var digest []byte
+	switch digestType {
+	case DigestSHA1:
+		sum := sha1.Sum(data) //nolint:gosec // SHA-1 is DS digest type 1
+		digest = sum[:]
+	case DigestSHA256:
+		sum := sha256.Sum256(data)
+		digest = sum[:]
+	case DigestSHA384:
+		sum := sha512.Sum384(data)
+		digest = sum[:]
+	default:
+		return DSRecord{}, fmt.Errorf("%w: unsupported digest type %d", core.ErrValidation, digestType)
+	}
+
+	record := DSRecord{
+		KeyTag:     key.KeyTag(),
+		Algorithm:  key.Algorithm,
+		DigestType: digestType,
+		Digest:     strings.ToUpper(hex.EncodeToString(digest)),
+	}
+	return record, record.Validate()
+}
+
+// canonicalName returns the lower case wire format of a domain name.
+func canonicalName(name string) ([]byte, error) {
+	ascii, err := ToASCII(strings.TrimSuffix(name, "."))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, label := range strings.Split(ascii, ".") {
+		buf.WriteByte(byte(len(label)))
+		buf.WriteString(label)
+	}
+	buf.WriteByte(0)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,111 @@
+package domain_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDS(t *testing.T) {
+	// RFC 4034 section 5.4 and RFC 4509 section 2.2.
+	rsa, err := domain.ParseDNSKEY(`256 3 5 AQOeiiR0GOMYkDshWoSKz9Xz fwJr1AYtsmx3TGkJaNXVbfi/
+		2pHm822aJ5iI9BMzNXxeYCmZ DRD99WYwYqUSdjMmmAphXdvx egXd/M5+X7OrzKBaMbCVdFLU
+		Uh6DhweJBjEVv5f2wwjM9Xzc nOf+EPbtG9DMBmADjFDc2w/r ljwvFw==`)
+	require.NoError(t, err)
+	require.Equal(t, uint16(60485), rsa.KeyTag())
+
+	ds, err := domain.ComputeDS("dskey.example.com.", rsa, domain.DigestSHA1)
+	require.NoError(t, err)
+	require.Equal(t, "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118", ds.String())
+
+	ds, err = domain.ComputeDS("DSKEY.example.com", rsa, domain.DigestSHA256)
+	require.NoError(t, err)
+	require.Equal(t, "60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", ds.String())
+
+	// RFC 8080 section 6.1.
+	ed25519, err := domain.ParseDNSKEY("257 3 15 l02Woi0iS8Aa25FQkUd9RMzZHJpBoRQwAQEX1SxZJA4=")
+	require.NoError(t, err)
+	ds, err = domain.ComputeDS("example.com", ed25519, domain.DigestSHA256)
+	require.NoError(t, err)
+	require.Equal(t, "3613 15 2 3AA5AB37EFCE57F737FC1627013FEE07BDF241BD10F3B1964AB55C78E79A304B", ds.String())
+
+	_, err = domain.ComputeDS("example.com", ed25519, domain.DigestSHA1)
+	require.ErrorIs(t, err, core.ErrValidation)
+
+	ed25519.Flags = 0
+	_, err = domain.ComputeDS("example.com", ed25519, domain.DigestSHA256)
+	require.ErrorIs(t, err, core.ErrValidation)
+}
+
+func TestDSRecordValidate(t *testing.T) {
+	valid := domain.DSRecord{
+		KeyTag:     3613,
+		Algorithm:  domain.AlgED25519,
+		DigestType: domain.DigestSHA256,
+		Digest:     "3AA5AB37EFCE57F737FC1627013FEE07BDF241BD10F3B1964AB55C78E79A304B",
+	}
+	require.NoError(t, valid.Validate())
+
+	tests := map[string]func(r *domain.DSRecord){
+		"deprecated algorithm": func(r *domain.DSRecord) { r.Algorithm = 3 },
+		"unknown digest type":  func(r *domain.DSRecord) { r.DigestType = 3 },
+		"short digest":         func(r *domain.DSRecord) { r.Digest = r.Digest[:40] },
+		"sha-1 with ed25519":   func(r *domain.DSRecord) { r.DigestType, r.Digest = domain.DigestSHA1, r.Digest[:40] },
+		"digest is not hex":    func(r *domain.DSRecord) { r.Digest = "Z" + r.Digest[1:] },
+		"sha-384 length for 2": func(r *domain.DSRecord) { r.Digest += r.Digest[:32] },
+		"sha-256 length for 4": func(r *domain.DSRecord) { r.DigestType = domain.DigestSHA384 },
+		"zero algorithm":       func(r *domain.DSRecord) { r.Algorithm = 0 },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := valid
+			mutate(&r)
+			require.ErrorIs(t, r.Validate(), core.ErrValidation)
+		})
+	}
+
+	var records []domain.DSRecord
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"keytag": "3613", "algorithm": 15, "digesttype": "2", "digest": "3aa5ab37efce57f737fc1627013fee07bdf241bd10f3b1964ab55c78e79a304b"},
+		"3613 15 2 3AA5AB37EFCE57F737FC1627013FEE07BDF241BD10F3B1964AB55C78E79A304B"
+	]`), &records))
+	require.Equal(t, []domain.DSRecord{valid, valid}, records)
+}
+
+func TestDNSSEC(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{DomainName: "example.com"})
+
+	key, err := domain.ParseDNSKEY("257 3 15 l02Woi0iS8Aa25FQkUd9RMzZHJpBoRQwAQEX1SxZJA4=")
+	require.NoError(t, err)
+	sha256, err := domain.ComputeDS("example.com", key, domain.DigestSHA256)
+	require.NoError(t, err)
+	sha384, err := domain.ComputeDS("example.com", key, domain.DigestSHA384)
+	require.NoError(t, err)
+
+	res, err := d.AddDNSSEC(context.Background(), orderID, []domain.DSRecord{sha256, sha384})
+	require.NoError(t, err)
+	require.Equal(t, "Success", res.ActionStatus)
+
+	detail, err := d.GetRegistrationOrderDetails(context.Background(), orderID, []domain.DetailOption{domain.DetailDNSSEC})
+	require.NoError(t, err)
+	require.Equal(t, []domain.DSRecord{sha256, sha384}, detail.DNSSec)
+
+	_, err = d.DeleteDNSSEC(context.Background(), orderID, []domain.DSRecord{sha256})
+	require.NoError(t, err)
+	order, _ := srv.Order(orderID)
+	require.Len(t, order.DNSSEC, 1)
+	require.Equal(t, "4", order.DNSSEC[0].DigestType)
+
+	calls := len(srv.Calls())
+	_, err = d.AddDNSSEC(context.Background(), orderID, []domain.DSRecord{{KeyTag: 1, Algorithm: 8, DigestType: 2, Digest: "00"}})
+	require.ErrorIs(t, err, core.ErrValidation)
+	_, err = d.DeleteDNSSEC(context.Background(), orderID, nil)
+	require.ErrorIs(t, err, core.ErrValidation)
+	require.Len(t, srv.Calls(), calls)
+}
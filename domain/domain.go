@@ -5,11 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
-	"strings"
 
 	"github.com/mrehanabbasi/go-logicboxes/core"
 )
@@ -20,6 +22,7 @@ type domain struct {
 
 type Domain interface {
 	CheckAvailability(ctx context.Context, domainsWithoutTLD, tlds []string) (Availabilities, error)
+	PremiumCheck(ctx context.Context, domainNames []string) (PremiumAvailabilities, error)
 	SuggestNames(ctx context.Context, keyword, tldOnly string, exactMatch, adult bool) (SuggestNames, error)
 	Register(
 		ctx context.Context,
@@ -32,6 +35,7 @@ type Domain interface {
 		discountAmount float64,
 		purchasePremiumDNS bool,
 	) (*RegisterResponse, error)
+	RegisterDomain(ctx context.Context, req *RegisterRequest) (*RegisterResponse, error)
 	Transfer(
 		ctx context.Context,
 		domainName, authCode, customerID, regContactID, adminContactID, techContactID, billingContactID, invoiceOption string,
@@ -40,10 +44,20 @@ type Domain interface {
 		attrName, attrValue string,
 		purchasePremiumDNS bool,
 	) (*RegisterResponse, error)
+	TransferDomain(ctx context.Context, req *TransferRequest) (*RegisterResponse, error)
+	Renew(
+		ctx context.Context,
+		orderID string,
+		years, expDate int,
+		purchasePrivacy, autoRenew bool,
+		invoiceOption InvoiceOption,
+		discountAmount float64,
+		purchasePremiumDNS bool,
+	) (*ActionResponse, error)
 	ValidatingTransferRequest(ctx context.Context, domainName string) (bool, error)
 	GetCustomerDefaultNameServers(ctx context.Context, customerID string) ([]string, error)
 	GetOrderID(ctx context.Context, domainName string) (string, error)
-	GetRegistrationOrderDetails(ctx context.Context, orderID string, options []string) (*OrderDetail, error)
+	GetRegistrationOrderDetails(ctx context.Context, orderID string, options []DetailOption) (*OrderDetail, error)
 	ModifyNameServers(ctx context.Context, orderID string, ns []string) (*NameServersResponse, error)
 	AddChildNameServer(ctx context.Context, orderID, cns string, ips []string) (*NameServersResponse, error)
 	ModifyChildNameServerHostName(ctx context.Context, orderID, oldCNS, newCNS string) (*NameServersResponse, error)
@@ -65,10 +79,22 @@ type Domain interface {
 	ApplyTheftProtectionLock(ctx context.Context, orderID string) (*TheftProtectionLockResponse, error)
 	RemoveTheftProtectionLock(ctx context.Context, orderID string) (*TheftProtectionLockResponse, error)
 	GetTheListOfLocksAppliedOnDomainName(ctx context.Context, orderID string) (*GetTheListOfLocksAppliedOnDomainNameResponse, error)
+	AddDNSSEC(ctx context.Context, orderID string, records []DSRecord) (*DNSSECResponse, error)
+	DeleteDNSSEC(ctx context.Context, orderID string, records []DSRecord) (*DNSSECResponse, error)
 	CancelTransfer(ctx context.Context, orderID string) (*CancelTransferResponse, error)
 	Suspend(ctx context.Context, orderID, reason string) (*TheftProtectionLockResponse, error)
 	Unsuspend(ctx context.Context, orderID string) (*TheftProtectionLockResponse, error)
 	Delete(ctx context.Context, orderID string) (*DeleteResponse, error)
+	Restore(ctx context.Context, orderID string, invoiceOption InvoiceOption) (*ActionResponse, error)
+	ResendTransferApprovalMail(ctx context.Context, orderID string) (*ActionResponse, error)
+	GetRAAVerification(ctx context.Context, orderID string) (*RAAVerification, error)
+	ResendRAAVerification(ctx context.Context, orderID string) (*ActionResponse, error)
+	ModifyTELWhoisPreference(ctx context.Context, orderID string, whoisType TELWhoisType, publish bool) (*ActionResponse, error)
+	ReleaseUKDomainName(ctx context.Context, orderID, newTag string) (*ActionResponse, error)
+	RecheckingNSWithDERegistry(ctx context.Context, orderID string) (*ActionResponse, error)
+	AssociatingOrDissociatingXXXMembershipTokenID(ctx context.Context, orderID, associationID string) (*ActionResponse, error)
+	SearchOrders(ctx context.Context, criteria OrderCriteria, offset, limit uint16) (*OrderSearchResult, error)
+	SearchAllOrders(ctx context.Context, criteria OrderCriteria, limit uint16) iter.Seq2[OrderSummary, error]
 }
 
 func New(c core.Core) Domain {
@@ -80,9 +106,18 @@ func (d *domain) CheckAvailability(ctx context.Context, domainName, tlds []strin
 		return Availabilities{}, errors.New("domainnames and tlds must not empty")
 	}
 
+	labels, err := toASCIIAll(domainName)
+	if err != nil {
+		return nil, err
+	}
+	asciiTLDs, err := toASCIIAll(tlds)
+	if err != nil {
+		return nil, err
+	}
+
 	data := url.Values{}
-	data["domain-name"] = append(data["domain-name"], domainName...)
-	data["tlds"] = append(data["tlds"], tlds...)
+	data["domain-name"] = append(data["domain-name"], labels...)
+	data["tlds"] = append(data["tlds"], asciiTLDs...)
 
 	resp, err := d.core.CallAPI(ctx, http.MethodGet, "domains", "available", data)
 	if err != nil {
@@ -96,11 +131,7 @@ func (d *domain) CheckAvailability(ctx context.Context, domainName, tlds []strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	availabilities := Availabilities{}
@@ -111,6 +142,42 @@ func (d *domain) CheckAvailability(ctx context.Context, domainName, tlds []strin
 	return availabilities, nil
 }
 
+func (d *domain) PremiumCheck(ctx context.Context, domainNames []string) (PremiumAvailabilities, error) {
+	if len(domainNames) == 0 {
+		return nil, errors.New("domainnames must not empty")
+	}
+
+	asciiNames, err := toASCIIAll(domainNames)
+	if err != nil {
+		return nil, err
+	}
+
+	data := url.Values{}
+	data["domain-name"] = append(data["domain-name"], asciiNames...)
+
+	resp, err := d.core.CallAPI(ctx, http.MethodGet, "domains", "premium-check", data)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, core.NewAPIError(resp, bytesResp)
+	}
+
+	premiums := PremiumAvailabilities{}
+	if err := json.Unmarshal(bytesResp, &premiums); err != nil {
+		return nil, err
+	}
+
+	return premiums, nil
+}
+
 func (d *domain) SuggestNames(ctx context.Context, keyword, tldOnly string, exactMatch, adult bool) (SuggestNames, error) {
 	data := make(url.Values)
 	data.Add("keyword", keyword)
@@ -130,12 +197,7 @@ func (d *domain) SuggestNames(ctx context.Context, keyword, tldOnly string, exac
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	suggestNames := SuggestNames{}
@@ -157,23 +219,33 @@ func (d *domain) Register(
 	discountAmount float64,
 	purchasePremiumDNS bool,
 ) (*RegisterResponse, error) {
-	data := make(url.Values)
-	data.Add("domain-name", domainName)
-	data.Add("years", strconv.Itoa(years))
-	data["ns"] = append(data["ns"], ns...)
-	data.Add("customer-id", customerID)
-	data.Add("reg-contact-id", regContactID)
-	data.Add("admin-contact-id", adminContactID)
-	data.Add("tech-contact-id", techContactID)
-	data.Add("billing-contact-id", billingContactID)
-	data.Add("invoice-option", invoiceOption)
-	data.Add("purchase-privacy", strconv.FormatBool(purchasePrivacy))
-	data.Add("protect-privacy", strconv.FormatBool(protectPrivacy))
-	data.Add("auto-renew", strconv.FormatBool(autoRenew))
-	data.Add("attr-name", attrName)
-	data.Add("attr-value", attrValue)
-	data.Add("discount-amount", strconv.FormatFloat(discountAmount, 'f', 2, 64))
-	data.Add("purchase-premium-dns", strconv.FormatBool(purchasePremiumDNS))
+	return d.RegisterDomain(ctx, &RegisterRequest{
+		DomainName:         domainName,
+		Years:              years,
+		NameServers:        ns,
+		CustomerID:         customerID,
+		RegContactID:       regContactID,
+		AdminContactID:     adminContactID,
+		TechContactID:      techContactID,
+		BillingContactID:   billingContactID,
+		InvoiceOption:      InvoiceOption(invoiceOption),
+		PurchasePrivacy:    purchasePrivacy,
+		ProtectPrivacy:     protectPrivacy,
+		AutoRenew:          autoRenew,
+		Attributes:         singleAttribute(attrName, attrValue),
+		DiscountAmount:     discountAmount,
+		PurchasePremiumDNS: purchasePremiumDNS,
+	})
+}
+
+func (d *domain) RegisterDomain(ctx context.Context, req *RegisterRequest) (*RegisterResponse, error) {
+	if req == nil {
+		return nil, errors.New("request must not nil")
+	}
+	data, err := req.URLValues()
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "register", data)
 	if err != nil {
@@ -187,11 +259,7 @@ func (d *domain) Register(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result RegisterResponse
@@ -210,22 +278,32 @@ func (d *domain) Transfer(
 	attrName, attrValue string,
 	purchasePremiumDNS bool,
 ) (*RegisterResponse, error) {
-	data := make(url.Values)
-	data.Add("domain-name", domainName)
-	data.Add("auth-code", authCode)
-	data.Add("customer-id", customerID)
-	data.Add("reg-contact-id", regContactID)
-	data.Add("admin-contact-id", adminContactID)
-	data.Add("tech-contact-id", techContactID)
-	data.Add("billing-contact-id", billingContactID)
-	data.Add("invoice-option", invoiceOption)
-	data.Add("purchase-privacy", strconv.FormatBool(purchasePrivacy))
-	data.Add("protect-privacy", strconv.FormatBool(protectPrivacy))
-	data.Add("auto-renew", strconv.FormatBool(autoRenew))
-	data["ns"] = append(data["ns"], ns...)
-	data.Add("attr-name", attrName)
-	data.Add("attr-value", attrValue)
-	data.Add("purchase-premium-dns", strconv.FormatBool(purchasePremiumDNS))
+	return d.TransferDomain(ctx, &TransferRequest{
+		DomainName:         domainName,
+		AuthCode:           authCode,
+		NameServers:        ns,
+		CustomerID:         customerID,
+		RegContactID:       regContactID,
+		AdminContactID:     adminContactID,
+		TechContactID:      techContactID,
+		BillingContactID:   billingContactID,
+		InvoiceOption:      InvoiceOption(invoiceOption),
+		PurchasePrivacy:    purchasePrivacy,
+		ProtectPrivacy:     protectPrivacy,
+		AutoRenew:          autoRenew,
+		Attributes:         singleAttribute(attrName, attrValue),
+		PurchasePremiumDNS: purchasePremiumDNS,
+	})
+}
+
+func (d *domain) TransferDomain(ctx context.Context, req *TransferRequest) (*RegisterResponse, error) {
+	if req == nil {
+		return nil, errors.New("request must not nil")
+	}
+	data, err := req.URLValues()
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "transfer", data)
 	if err != nil {
@@ -239,11 +317,7 @@ func (d *domain) Transfer(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result RegisterResponse
@@ -270,11 +344,7 @@ func (d *domain) ValidatingTransferRequest(ctx context.Context, domainName strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return false, err
-		}
-		return false, errors.New(strings.ToLower(errResponse.Message))
+		return false, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result bool
@@ -288,68 +358,141 @@ func (d *domain) ValidatingTransferRequest(ctx context.Context, domainName strin
 func (d *domain) Renew(
 	ctx context.Context,
 	orderID string,
-	_, expDate int, // years & expDate
+	years, expDate int,
 	purchasePrivacy, autoRenew bool,
-	invoiceOption string,
+	invoiceOption InvoiceOption,
 	discountAmount float64,
 	purchasePremiumDNS bool,
-) error {
+) (*ActionResponse, error) {
+	if err := invoiceOption.Validate(); err != nil {
+		return nil, err
+	}
+	if years < 1 || years > 10 {
+		return nil, fmt.Errorf("%w: years must be in range of 1 to 10", core.ErrValidation)
+	}
+
 	data := make(url.Values)
 	data.Add("order-id", orderID)
+	data.Add("years", strconv.Itoa(years))
 	data.Add("exp-date", strconv.Itoa(expDate))
 	data.Add("purchase-privacy", strconv.FormatBool(purchasePrivacy))
 	data.Add("auto-renew", strconv.FormatBool(autoRenew))
-	data.Add("invoice-option", invoiceOption)
+	data.Add("invoice-option", string(invoiceOption))
 	data.Add("discount-amount", strconv.FormatFloat(discountAmount, 'f', 2, 64))
 	data.Add("purchase-premium-dns", strconv.FormatBool(purchasePremiumDNS))
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "renew", data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	bytesResp, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
-	return nil
+	return decodeActionResponse(bytesResp)
 }
 
-func (d *domain) SearchOrders(ctx context.Context, criteria OrderCriteria) error {
+func (d *domain) SearchOrders(ctx context.Context, criteria OrderCriteria, offset, limit uint16) (*OrderSearchResult, error) {
+	if limit < 10 || limit > 500 {
+		return nil, errors.New("limit must be in range of 10 to 500")
+	}
+	if offset <= 0 {
+		return nil, errors.New("offset must greater than 0")
+	}
+
 	urlValues, err := criteria.URLValues()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	urlValues.Add("no-of-records", strconv.FormatUint(uint64(limit), 10))
+	urlValues.Add("page-no", strconv.FormatUint(uint64(offset), 10))
+
 	resp, err := d.core.CallAPI(ctx, http.MethodGet, "domains", "search", urlValues)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	bytesResp, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
+		return nil, core.NewAPIError(resp, bytesResp)
+	}
+
+	var buffer map[string]core.JSONBytes
+	if err := json.Unmarshal(bytesResp, &buffer); err != nil {
+		return nil, err
+	}
+
+	ret := &OrderSearchResult{
+		RequestedLimit:  limit,
+		RequestedOffset: offset,
+	}
+	var keys []int
+	for key, dataBytes := range buffer {
+		switch key {
+		case "recsindb":
+			ret.TotalMatched, _ = strconv.Atoi(string(dataBytes))
+		case "recsonpage":
+			ret.OnPage, _ = strconv.Atoi(string(dataBytes))
+		default:
+			if idx, err := strconv.Atoi(key); err == nil {
+				keys = append(keys, idx)
+			}
+		}
+	}
+
+	// Orders are keyed by their 1-based position on the page.
+	slices.Sort(keys)
+	for _, idx := range keys {
+		var order OrderSummary
+		if err := json.Unmarshal(buffer[strconv.Itoa(idx)], &order); err != nil {
+			return nil, err
 		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		ret.Orders = append(ret.Orders, order)
 	}
 
-	return nil
+	return ret, nil
+}
+
+// SearchAllOrders iterates over all orders matching criteria, fetching limit orders per page.
+// Iteration stops at the first error, which is yielded with a zero OrderSummary.
+func (d *domain) SearchAllOrders(ctx context.Context, criteria OrderCriteria, limit uint16) iter.Seq2[OrderSummary, error] {
+	return func(yield func(OrderSummary, error) bool) {
+		var seen int
+		for page := uint16(1); page > 0; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(OrderSummary{}, err)
+				return
+			}
+
+			result, err := d.SearchOrders(ctx, criteria, page, limit)
+			if err != nil {
+				yield(OrderSummary{}, err)
+				return
+			}
+			for _, order := range result.Orders {
+				if !yield(order, nil) {
+					return
+				}
+			}
+
+			seen += len(result.Orders)
+			if len(result.Orders) == 0 || seen >= result.TotalMatched {
+				return
+			}
+		}
+	}
 }
 
 func (d *domain) GetCustomerDefaultNameServers(ctx context.Context, customerID string) ([]string, error) {
@@ -368,11 +511,7 @@ func (d *domain) GetCustomerDefaultNameServers(ctx context.Context, customerID s
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	result := make([]string, 0)
@@ -384,8 +523,13 @@ func (d *domain) GetCustomerDefaultNameServers(ctx context.Context, customerID s
 }
 
 func (d *domain) GetOrderID(ctx context.Context, domainName string) (string, error) {
+	asciiName, err := ToASCII(domainName)
+	if err != nil {
+		return "", err
+	}
+
 	data := make(url.Values)
-	data.Add("domain-name", domainName)
+	data.Add("domain-name", asciiName)
 
 	resp, err := d.core.CallAPI(ctx, http.MethodGet, "domains", "orderid", data)
 	if err != nil {
@@ -399,20 +543,25 @@ func (d *domain) GetOrderID(ctx context.Context, domainName string) (string, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return "", err
-		}
-		return "", errors.New(strings.ToLower(errResponse.Message))
+		return "", core.NewAPIError(resp, bytesResp)
 	}
 
 	return string(bytesResp), nil
 }
 
-func (d *domain) GetRegistrationOrderDetails(ctx context.Context, orderID string, options []string) (*OrderDetail, error) {
+func (d *domain) GetRegistrationOrderDetails(ctx context.Context, orderID string, options []DetailOption) (*OrderDetail, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("%w: options must not empty", core.ErrValidation)
+	}
+
 	data := make(url.Values)
 	data.Add("order-id", orderID)
-	data["options"] = append(data["options"], options...)
+	for _, option := range options {
+		if err := option.Validate(); err != nil {
+			return nil, err
+		}
+		data.Add("options", string(option))
+	}
 
 	resp, err := d.core.CallAPI(ctx, http.MethodGet, "domains", "details", data)
 	if err != nil {
@@ -426,11 +575,7 @@ func (d *domain) GetRegistrationOrderDetails(ctx context.Context, orderID string
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var orderDetail OrderDetail
@@ -458,11 +603,7 @@ func (d *domain) ModifyNameServers(ctx context.Context, orderID string, ns []str
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result NameServersResponse
@@ -491,11 +632,7 @@ func (d *domain) AddChildNameServer(ctx context.Context, orderID, cns string, ip
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result NameServersResponse
@@ -524,11 +661,7 @@ func (d *domain) ModifyChildNameServerHostName(ctx context.Context, orderID, old
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result NameServersResponse
@@ -558,11 +691,7 @@ func (d *domain) ModifyChildNameServerIPAddress(ctx context.Context, orderID, cn
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result NameServersResponse
@@ -591,11 +720,7 @@ func (d *domain) DeletingChildNameServerIPAddress(ctx context.Context, orderID,
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result NameServersResponse
@@ -635,11 +760,7 @@ func (d *domain) ModifyContacts(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result ModifyAuthCodeResponse
@@ -673,11 +794,7 @@ func (d *domain) ModifyPrivacyProtectionStatus(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result ModifyPrivacyProtectionStatusResponse
@@ -705,11 +822,7 @@ func (d *domain) ModifyAuthCode(ctx context.Context, orderID, authCode string) (
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result ModifyAuthCodeResponse
@@ -736,11 +849,7 @@ func (d *domain) ApplyTheftProtectionLock(ctx context.Context, orderID string) (
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result TheftProtectionLockResponse
@@ -767,11 +876,7 @@ func (d *domain) RemoveTheftProtectionLock(ctx context.Context, orderID string)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result TheftProtectionLockResponse
@@ -801,11 +906,7 @@ func (d *domain) GetTheListOfLocksAppliedOnDomainName(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result GetTheListOfLocksAppliedOnDomainNameResponse
@@ -816,85 +917,156 @@ func (d *domain) GetTheListOfLocksAppliedOnDomainName(
 	return &result, nil
 }
 
-func (d *domain) ModifyTELWhoisPreference(ctx context.Context, orderID, whoisType, publish string) error {
+func (d *domain) ModifyTELWhoisPreference(
+	ctx context.Context,
+	orderID string,
+	whoisType TELWhoisType,
+	publish bool,
+) (*ActionResponse, error) {
+	switch {
+	case whoisType != TELWhoisNatural && whoisType != TELWhoisLegal:
+		return nil, fmt.Errorf("%w: invalid whois type %q", core.ErrValidation, whoisType)
+	case whoisType == TELWhoisLegal && !publish:
+		return nil, fmt.Errorf("%w: whois of legal persons must be published", core.ErrValidation)
+	}
+
 	data := make(url.Values)
 	data.Add("order-id", orderID)
-	data.Add("whois-type", whoisType)
-	data.Add("publish", publish)
+	data.Add("whois-type", string(whoisType))
+	if publish {
+		data.Add("publish", "y")
+	} else {
+		data.Add("publish", "n")
+	}
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "tel/modify-whois-pref", data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	bytesResp, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
-	return nil
+	return decodeActionResponse(bytesResp)
 }
 
-func (d *domain) ResendTransferApprovalMail(ctx context.Context, orderID string) error {
+func (d *domain) ResendTransferApprovalMail(ctx context.Context, orderID string) (*ActionResponse, error) {
 	data := make(url.Values)
 	data.Add("order-id", orderID)
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "resend-rfa", data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	bytesResp, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
+	}
+
+	return decodeActionResponse(bytesResp)
+}
+
+func (d *domain) GetRAAVerification(ctx context.Context, orderID string) (*RAAVerification, error) {
+	detail, err := d.GetRegistrationOrderDetails(ctx, orderID, []DetailOption{DetailOrderDetails})
+	if err != nil {
+		return nil, err
+	}
+	return raaVerificationOf(detail), nil
+}
+
+func (d *domain) ResendRAAVerification(ctx context.Context, orderID string) (*ActionResponse, error) {
+	data := make(url.Values)
+	data.Add("order-id", orderID)
+
+	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "raa/resend-verification", data)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
-	return nil
+	return decodeActionResponse(bytesResp)
 }
 
-func (d *domain) ReleaseUKDomainName(ctx context.Context, orderID, newTag string) error {
+func (d *domain) ReleaseUKDomainName(ctx context.Context, orderID, newTag string) (*ActionResponse, error) {
 	data := make(url.Values)
 	data.Add("order-id", orderID)
 	data.Add("new-tag", newTag)
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "uk/release", data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	bytesResp, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
+	}
+
+	return decodeActionResponse(bytesResp)
+}
+
+func (d *domain) AddDNSSEC(ctx context.Context, orderID string, records []DSRecord) (*DNSSECResponse, error) {
+	return d.dnssecAction(ctx, "add-dnssec", orderID, records)
+}
+
+func (d *domain) DeleteDNSSEC(ctx context.Context, orderID string, records []DSRecord) (*DNSSECResponse, error) {
+	return d.dnssecAction(ctx, "del-dnssec", orderID, records)
+}
+
+func (d *domain) dnssecAction(ctx context.Context, apiName, orderID string, records []DSRecord) (*DNSSECResponse, error) {
+	data, err := dnssecValues(orderID, records)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", apiName, data)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, core.NewAPIError(resp, bytesResp)
+	}
+
+	var result DNSSECResponse
+	if err := json.Unmarshal(bytesResp, &result); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &result, nil
 }
 
 func (d *domain) CancelTransfer(ctx context.Context, orderID string) (*CancelTransferResponse, error) {
@@ -913,11 +1085,7 @@ func (d *domain) CancelTransfer(ctx context.Context, orderID string) (*CancelTra
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result CancelTransferResponse
@@ -945,11 +1113,7 @@ func (d *domain) Suspend(ctx context.Context, orderID, reason string) (*TheftPro
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result TheftProtectionLockResponse
@@ -976,11 +1140,7 @@ func (d *domain) Unsuspend(ctx context.Context, orderID string) (*TheftProtectio
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result TheftProtectionLockResponse
@@ -1007,11 +1167,7 @@ func (d *domain) Delete(ctx context.Context, orderID string) (*DeleteResponse, e
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result DeleteResponse
@@ -1022,82 +1178,77 @@ func (d *domain) Delete(ctx context.Context, orderID string) (*DeleteResponse, e
 	return &result, nil
 }
 
-func (d *domain) Restore(ctx context.Context, orderID, invoiceOption string) error {
+func (d *domain) Restore(ctx context.Context, orderID string, invoiceOption InvoiceOption) (*ActionResponse, error) {
+	if err := invoiceOption.Validate(); err != nil {
+		return nil, err
+	}
+
 	data := make(url.Values)
 	data.Add("order-id", orderID)
-	data.Add("invoice-option", invoiceOption)
+	data.Add("invoice-option", string(invoiceOption))
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "restore", data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	bytesResp, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
-	return nil
+	return decodeActionResponse(bytesResp)
 }
 
-func (d *domain) RecheckingNSWithDERegistry(ctx context.Context, orderID string) error {
+func (d *domain) RecheckingNSWithDERegistry(ctx context.Context, orderID string) (*ActionResponse, error) {
 	data := make(url.Values)
 	data.Add("order-id", orderID)
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "de/recheck-ns", data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	bytesResp, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
-	return nil
+	return decodeActionResponse(bytesResp)
 }
 
-func (d *domain) AssociatingOrDissociatingXXXMembershipTokenID(ctx context.Context, orderID, associationID string) error {
+func (d *domain) AssociatingOrDissociatingXXXMembershipTokenID(
+	ctx context.Context,
+	orderID, associationID string,
+) (*ActionResponse, error) {
 	data := make(url.Values)
 	data.Add("order-id", orderID)
 	data.Add("association-id", associationID)
 
 	resp, err := d.core.CallAPI(ctx, http.MethodPost, "domains", "dotxxx/association-details", data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	bytesResp, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return err
-		}
-		return errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
-	return nil
+	return decodeActionResponse(bytesResp)
 }
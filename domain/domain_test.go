@@ -23,25 +23,38 @@ var (
 	authCode   = os.Getenv("TEST_AUTH_CODE")
 )
 
+// skipWithoutCredentials skips tests against the live test API unless its credentials are set.
+// Hermetic tests use the rctest package instead.
+func skipWithoutCredentials(t *testing.T) {
+	t.Helper()
+	if os.Getenv("RESELLER_ID") == "" || os.Getenv("API_KEY") == "" {
+		t.Skip("RESELLER_ID and API_KEY are not set")
+	}
+}
+
 func TestSuggestNames(t *testing.T) {
+	skipWithoutCredentials(t)
 	res, err := d.SuggestNames(context.Background(), "domain", "", false, false)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 }
 
 func TestGetOrderID(t *testing.T) {
+	skipWithoutCredentials(t)
 	res, err := d.GetOrderID(context.Background(), domainName)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 }
 
 func TestGetRegistrationOrderDetails(t *testing.T) {
-	res, err := d.GetRegistrationOrderDetails(context.Background(), orderID, []string{"All"})
+	skipWithoutCredentials(t)
+	res, err := d.GetRegistrationOrderDetails(context.Background(), orderID, []DetailOption{DetailAll})
 	require.NoError(t, err)
 	require.NotNil(t, res)
 }
 
 func TestModifyNameServers(t *testing.T) {
+	skipWithoutCredentials(t)
 	res, err := d.ModifyNameServers(context.Background(), orderID, []string{"ns1.domain.asia"})
 	require.NoError(t, err)
 	require.NotNil(t, res)
@@ -52,30 +65,35 @@ func TestModifyNameServers(t *testing.T) {
 }
 
 func TestAddChildNameServer(t *testing.T) {
+	skipWithoutCredentials(t)
 	res, err := d.AddChildNameServer(context.Background(), orderID, "new."+domainName, []string{"0.0.0.0", "1.1.1.1"})
 	require.NoError(t, err)
 	require.NotNil(t, res)
 }
 
 func TestModifyPrivacyProtectionStatus(t *testing.T) {
+	skipWithoutCredentials(t)
 	res, err := d.ModifyPrivacyProtectionStatus(context.Background(), orderID, true, "some reason")
 	require.NoError(t, err)
 	require.NotNil(t, res)
 }
 
 func TestModifyAuthCode(t *testing.T) {
+	skipWithoutCredentials(t)
 	res, err := d.ModifyAuthCode(context.Background(), orderID, authCode)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 }
 
 func TestApplyTheftProtectionLock(t *testing.T) {
+	skipWithoutCredentials(t)
 	res, err := d.ApplyTheftProtectionLock(context.Background(), orderID)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 }
 
 func TestGetTheListOfLocksAppliedOnDomainName(t *testing.T) {
+	skipWithoutCredentials(t)
 	res, err := d.GetTheListOfLocksAppliedOnDomainName(context.Background(), orderID)
 	require.NoError(t, err)
 	require.NotNil(t, res)
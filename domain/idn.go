@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"golang.org/x/net/idna"
+)
+
+// IDNLanguageAttr is the attribute carrying the language of an IDN registration.
+const IDNLanguageAttr = "idnLanguageCode"
+
+// idnProfile maps input (case folding, width) and then validates it per IDNA2008,
+// including the bidi and contextual rules.
+var idnProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.StrictDomainName(true),
+	idna.VerifyDNSLength(true),
+)
+
+type idnScript struct {
+	name     string
+	table    *unicode.RangeTable
+	language string
+}
+
+// idnScripts lists the scripts accepted in a label with their default language. Latin has none
+// since it is shared by too many languages.
+var idnScripts = []idnScript{
+	{"Latin", unicode.Latin, ""},
+	{"Cyrillic", unicode.Cyrillic, "rus"},
+	{"Greek", unicode.Greek, "gre"},
+	{"Arabic", unicode.Arabic, "ara"},
+	{"Hebrew", unicode.Hebrew, "heb"},
+	{"Han", unicode.Han, "chi"},
+	{"Hiragana", unicode.Hiragana, "jpn"},
+	{"Katakana", unicode.Katakana, "jpn"},
+	{"Hangul", unicode.Hangul, "kor"},
+	{"Thai", unicode.Thai, "tha"},
+	{"Devanagari", unicode.Devanagari, "hin"},
+	{"Armenian", unicode.Armenian, "arm"},
+	{"Georgian", unicode.Georgian, "geo"},
+}
+
+// ToASCII converts a domain name or a single label to its A-label form. Names are validated per
+// IDNA2008 only, whatever their script. Plain ASCII names are only lowercased.
+func ToASCII(name string) (string, error) {
+	ascii, err := idnProfile.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w", core.ErrValidation, name, err)
+	}
+	return ascii, nil
+}
+
+// ToUnicode converts a domain name to its Unicode form for display.
+func ToUnicode(name string) (string, error) {
+	unicodeName, err := idnProfile.ToUnicode(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w", core.ErrValidation, name, err)
+	}
+	return unicodeName, nil
+}
+
+// IsIDN reports whether the name has an internationalized label, in either form.
+func IsIDN(name string) bool {
+	for _, label := range strings.Split(name, ".") {
+		if strings.HasPrefix(strings.ToLower(label), "xn--") {
+			return true
+		}
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+// IDNLanguage returns the default registry language code of the second-level label of an IDN.
+// The label must be written in a single script, with Japanese and Korean labels allowed to mix
+// Han. Latin labels and scripts without a known default require an explicit language.
+func IDNLanguage(name string) (string, error) {
+	unicodeName, err := ToUnicode(name)
+	if err != nil {
+		return "", err
+	}
+	label, _, _ := strings.Cut(unicodeName, ".")
+	script, err := labelScript(label)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q: %w, the language must be set explicitly", core.ErrValidation, name, err)
+	}
+	if script.language == "" {
+		return "", fmt.Errorf("%w: %q: the language of %s names must be set explicitly",
+			core.ErrValidation, name, script.name)
+	}
+	return script.language, nil
+}
+
+// idnLanguageRequired reports whether registering the name requires IDNLanguageAttr.
+func idnLanguageRequired(asciiName string) bool {
+	if !IsIDN(asciiName) {
+		return false
+	}
+	info, ok := core.DefaultTLDCatalog().LookupDomain(asciiName)
+	return ok && info.IDNLanguage
+}
+
+// labelScript returns the script of a label. Digits, hyphens and combining marks are neutral.
+func labelScript(label string) (idnScript, error) {
+	found := map[string]idnScript{}
+	for _, r := range label {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		script, ok := scriptOf(r)
+		if !ok {
+			return idnScript{}, fmt.Errorf("unsupported character %q", r)
+		}
+		found[script.name] = script
+	}
+
+	switch {
+	case len(found) == 0:
+		return idnScripts[0], nil
+	case len(found) == 1:
+		for _, script := range found {
+			return script, nil
+		}
+	}
+
+	delete(found, "Han")
+	japanese := true
+	for name := range found {
+		japanese = japanese && (name == "Hiragana" || name == "Katakana")
+	}
+	if japanese {
+		return idnScript{"Japanese", nil, "jpn"}, nil
+	}
+	if _, ok := found["Hangul"]; ok && len(found) == 1 {
+		return found["Hangul"], nil
+	}
+	return idnScript{}, errors.New("label mixes scripts")
+}
+
+func scriptOf(r rune) (idnScript, bool) {
+	for _, script := range idnScripts {
+		if unicode.Is(script.table, r) {
+			return script, true
+		}
+	}
+	return idnScript{}, false
+}
+
+func toASCIIAll(names []string) ([]string, error) {
+	ret := make([]string, len(names))
+	for i, name := range names {
+		ascii, err := ToASCII(name)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = ascii
+	}
+	return ret, nil
+}
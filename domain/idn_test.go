@@ -0,0 +1,153 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToASCII(t *testing.T) {
+	tests := map[string]string{
+		"example.com":     "example.com",
+		"Bücher.com":      "xn--bcher-kva.com",
+		"пример.рф":       "xn--e1afmkfd.xn--p1ai",
+		"例え.jp":           "xn--r8jz45g.jp",
+		"xn--p1ai":        "xn--p1ai",
+		"ＥＸＡＭＰＬＥ.com":     "example.com",
+		"தமிழ்.com":       "xn--rlcus7b3d.com",
+		"বাংলা.com":       "xn--54b7fta0cc.com",
+		"ትግራይ.com":        "xn--iyd1mxn7d.com",
+		"p\u0430ypal.com": "xn--pypal-4ve.com",
+	}
+	for in, want := range tests {
+		got, err := domain.ToASCII(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got)
+	}
+
+	for _, invalid := range []string{
+		"a\u200db.com", // ZERO WIDTH JOINER outside its allowed context
+		"-leading.com",
+		"not a host.com",
+	} {
+		_, err := domain.ToASCII(invalid)
+		require.ErrorIs(t, err, core.ErrValidation, invalid)
+	}
+
+	// A-labels of scripts without a default language are accepted as is.
+	got, err := domain.ToASCII("XN--RLCUS7B3D.com")
+	require.NoError(t, err)
+	require.Equal(t, "xn--rlcus7b3d.com", got)
+
+	name, err := domain.ToUnicode("xn--e1afmkfd.xn--p1ai")
+	require.NoError(t, err)
+	require.Equal(t, "пример.рф", name)
+}
+
+func TestIDNLanguage(t *testing.T) {
+	language, err := domain.IDNLanguage("xn--e1afmkfd.com")
+	require.NoError(t, err)
+	require.Equal(t, "rus", language)
+
+	language, err = domain.IDNLanguage("例え.com")
+	require.NoError(t, err)
+	require.Equal(t, "jpn", language)
+
+	for _, name := range []string{
+		"bücher.com",
+		"p\u0430ypal.com", // CYRILLIC SMALL LETTER A among Latin letters
+		"தமிழ்.com",
+		"xn--rlcus7b3d.com",
+	} {
+		_, err = domain.IDNLanguage(name)
+		require.ErrorIs(t, err, core.ErrValidation, name)
+	}
+}
+
+func TestRegisterIDN(t *testing.T) {
+	srv, d := newFake(t)
+	customerID, contactID := addRegistrant(srv)
+
+	req := registerRequest(customerID, contactID)
+	req.DomainName = "пример.com"
+	res, err := d.RegisterDomain(context.Background(), req)
+	require.NoError(t, err)
+
+	order, ok := srv.Order(res.EntityID)
+	require.True(t, ok)
+	require.Equal(t, "xn--e1afmkfd.com", order.DomainName)
+	require.Equal(t, map[string]string{domain.IDNLanguageAttr: "rus"}, order.Attributes)
+
+	orderID, err := d.GetOrderID(context.Background(), "ПРИМЕР.com")
+	require.NoError(t, err)
+	require.Equal(t, res.EntityID, orderID)
+
+	req.DomainName = "bücher.com"
+	_, err = d.RegisterDomain(context.Background(), req)
+	require.ErrorIs(t, err, core.ErrValidation)
+
+	req.IDNLanguage = "GER"
+	res, err = d.RegisterDomain(context.Background(), req)
+	require.NoError(t, err)
+	order, _ = srv.Order(res.EntityID)
+	require.Equal(t, "xn--bcher-kva.com", order.DomainName)
+	require.Equal(t, "ger", order.Attributes[domain.IDNLanguageAttr])
+
+	req.DomainName, req.IDNLanguage = "தமிழ்.com", ""
+	_, err = d.RegisterDomain(context.Background(), req)
+	require.ErrorIs(t, err, core.ErrValidation)
+
+	req.IDNLanguage = "tam"
+	res, err = d.RegisterDomain(context.Background(), req)
+	require.NoError(t, err)
+	order, _ = srv.Order(res.EntityID)
+	require.Equal(t, "xn--rlcus7b3d.com", order.DomainName)
+	require.Equal(t, "tam", order.Attributes[domain.IDNLanguageAttr])
+
+	availabilities, err := d.CheckAvailability(context.Background(), []string{"пример"}, []string{"com", "рф"})
+	require.NoError(t, err)
+	require.Equal(t, domain.DomRegThroughUs, availabilities["xn--e1afmkfd.com"].Status)
+	require.Equal(t, domain.DomRegUnregistered, availabilities["xn--e1afmkfd.xn--p1ai"].Status)
+}
+
+func TestRegisterIDNTLD(t *testing.T) {
+	srv, d := newFake(t)
+	customerID, contactID := addRegistrant(srv)
+
+	tests := map[string]string{
+		"пример.рф":        "xn--e1afmkfd.xn--p1ai",
+		"例え.中国":            "xn--r8jz45g.xn--fiqs8s",
+		"Example.РФ":       "example.xn--p1ai",
+		"xn--e1afmkfd.орг": "xn--e1afmkfd.xn--c1avg",
+	}
+	for name, want := range tests {
+		req := registerRequest(customerID, contactID)
+		req.DomainName = name
+		req.NameServers = []string{"ns1.пример.рф", "ns2.example.net"}
+		res, err := d.RegisterDomain(context.Background(), req)
+		require.NoError(t, err, name)
+
+		order, ok := srv.Order(res.EntityID)
+		require.True(t, ok)
+		require.Equal(t, want, order.DomainName)
+		require.Equal(t, []string{"ns1.xn--e1afmkfd.xn--p1ai", "ns2.example.net"}, order.NameServers)
+	}
+
+	res, err := d.TransferDomain(context.Background(), &domain.TransferRequest{
+		DomainName:       "пример2.рф",
+		AuthCode:         "secret",
+		CustomerID:       customerID,
+		RegContactID:     contactID,
+		AdminContactID:   contactID,
+		TechContactID:    contactID,
+		BillingContactID: contactID,
+		InvoiceOption:    domain.InvoiceNo,
+	})
+	require.NoError(t, err)
+	order, ok := srv.Order(res.EntityID)
+	require.True(t, ok)
+	require.Equal(t, "xn--2-itbiqngd.xn--p1ai", order.DomainName)
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// OrderState is the lifecycle stage of a domain order.
+type OrderState string
+
+// Const for order states.
+const (
+	OrderActive OrderState = "active"
+	// OrderExpiredGrace is expired but still renewable within the TLD's renew grace period.
+	OrderExpiredGrace OrderState = "expired-grace"
+	// OrderRedemption is deleted at the registry and restorable.
+	OrderRedemption OrderState = "redemption"
+	// OrderPendingDelete is past the restore period and awaits its purge.
+	OrderPendingDelete OrderState = "pending-delete"
+	OrderDeleted       OrderState = "deleted"
+	OrderSuspended     OrderState = "suspended"
+	OrderTransferIn    OrderState = "transfer-in"
+	OrderTransferOut   OrderState = "transfer-out"
+	OrderUnknown       OrderState = "unknown"
+)
+
+// OrderOperation is an operation whose availability depends on the order state.
+type OrderOperation string
+
+// Const for order operations.
+const (
+	OperationRenew       OrderOperation = "renew"
+	OperationRestore     OrderOperation = "restore"
+	OperationDelete      OrderOperation = "delete"
+	OperationTransferOut OrderOperation = "transfer out"
+)
+
+// LifecycleDetailOptions are the details options OrderLifecycleOf needs.
+var LifecycleDetailOptions = []DetailOption{DetailOrderDetails, DetailDomainStatus}
+
+// OrderLifecycle is the state of an order with the facts deciding which operations it allows.
+type OrderLifecycle struct {
+	OrderID    string
+	DomainName string
+	State      OrderState
+	ExpiryTime time.Time
+	// AllowDeletion is set by the platform while the order is within its add grace period.
+	AllowDeletion bool
+	// TransferLocked reports a theft protection or customer lock.
+	TransferLocked bool
+}
+
+// OrderLifecycleOf derives the lifecycle of an order at the given time from details fetched with
+// LifecycleDetailOptions. Expired orders without a registry status are placed in the grace,
+// redemption or pending delete period using the TLD catalog, with the usual gTLD periods for TLDs
+// missing from it.
+func OrderLifecycleOf(detail *OrderDetail, now time.Time) OrderLifecycle {
+	l := OrderLifecycle{
+		OrderID:       detail.OrderID,
+		DomainName:    detail.DomainName,
+		State:         OrderUnknown,
+		ExpiryTime:    detail.EndTime.ToTime(),
+		AllowDeletion: detail.AllowDeletion.ToBool(),
+		TransferLocked: slices.ContainsFunc(detail.OrderStatus, func(s string) bool {
+			s = strings.ToLower(s)
+			return s == "transferlock" || s == "customerlock"
+		}),
+	}
+	hasStatus := func(status string) bool {
+		return slices.ContainsFunc(detail.DomainStatus, func(s string) bool { return strings.EqualFold(s, status) })
+	}
+
+	switch core.EntityStatus(detail.CurrentStatus) {
+	case core.StatusActive:
+		l.State = l.activeState(now, hasStatus)
+	case core.StatusSuspended:
+		l.State = OrderSuspended
+		if detail.IsOrderSuspendedUponExpiry.ToBool() {
+			l.State = l.activeState(now, hasStatus)
+		}
+	case core.StatusInActive:
+		l.State = OrderTransferIn
+	case core.StatusRestorable:
+		l.State = OrderRedemption
+	case core.StatusDeleted, core.StatusArchived:
+		l.State = OrderDeleted
+	}
+	return l
+}
+
+func (l OrderLifecycle) activeState(now time.Time, hasStatus func(string) bool) OrderState {
+	switch {
+	case hasStatus("redemptionperiod"):
+		return OrderRedemption
+	case hasStatus("pendingdelete"):
+		return OrderPendingDelete
+	case hasStatus("pendingtransfer"):
+		return OrderTransferOut
+	case l.ExpiryTime.IsZero() || now.Before(l.ExpiryTime):
+		return OrderActive
+	}
+
+	info, ok := core.DefaultTLDCatalog().LookupDomain(l.DomainName)
+	if !ok {
+		info = core.TLDInfo{RenewGraceDays: core.DefaultRenewGraceDays, RestoreDays: core.DefaultRestoreDays}
+	}
+	graceEnd := l.ExpiryTime.AddDate(0, 0, info.RenewGraceDays)
+	switch {
+	case now.Before(graceEnd):
+		return OrderExpiredGrace
+	case now.Before(graceEnd.AddDate(0, 0, info.RestoreDays)):
+		return OrderRedemption
+	}
+	return OrderPendingDelete
+}
+
+// CanRenew reports whether the order can be renewed. Suspended orders can be renewed.
+func (l OrderLifecycle) CanRenew() bool {
+	return l.State == OrderActive || l.State == OrderExpiredGrace || l.State == OrderSuspended
+}
+
+// CanRestore reports whether the order is in its redemption period.
+func (l OrderLifecycle) CanRestore() bool {
+	return l.State == OrderRedemption
+}
+
+// CanDelete reports whether the platform allows deleting the order.
+func (l OrderLifecycle) CanDelete() bool {
+	return l.AllowDeletion && (l.State == OrderActive || l.State == OrderExpiredGrace)
+}
+
+// CanTransferOut reports whether the domain can be transferred to another registrar.
+func (l OrderLifecycle) CanTransferOut() bool {
+	return l.State == OrderActive && !l.TransferLocked
+}
+
+// Validate returns a core.ErrValidation error when the operation is not allowed in the current state.
+func (l OrderLifecycle) Validate(op OrderOperation) error {
+	var ok bool
+	switch op {
+	case OperationRenew:
+		ok = l.CanRenew()
+	case OperationRestore:
+		ok = l.CanRestore()
+	case OperationDelete:
+		ok = l.CanDelete()
+	case OperationTransferOut:
+		ok = l.CanTransferOut()
+	default:
+		return fmt.Errorf("%w: unknown operation %q", core.ErrValidation, op)
+	}
+	if ok {
+		return nil
+	}
+	if op == OperationDelete && !l.AllowDeletion {
+		return fmt.Errorf("%w: cannot delete order %s: deletion is not allowed", core.ErrValidation, l.OrderID)
+	}
+	if op == OperationTransferOut && l.TransferLocked {
+		return fmt.Errorf("%w: cannot transfer out order %s: the domain is locked", core.ErrValidation, l.OrderID)
+	}
+	return fmt.Errorf("%w: cannot %s order %s in state %s", core.ErrValidation, op, l.OrderID, l.State)
+}
+
+// lifecycleGuard checks the order lifecycle before the calls that depend on it.
+type lifecycleGuard struct {
+	Domain
+}
+
+// WithLifecycleChecks wraps d so that Renew, Restore and Delete fetch the order details first and
+// fail with core.ErrValidation, without calling the API, when the order state does not allow them.
+func WithLifecycleChecks(d Domain) Domain {
+	return &lifecycleGuard{Domain: d}
+}
+
+func (g *lifecycleGuard) check(ctx context.Context, orderID string, op OrderOperation) error {
+	detail, err := g.GetRegistrationOrderDetails(ctx, orderID, LifecycleDetailOptions)
+	if err != nil {
+		return err
+	}
+	return OrderLifecycleOf(detail, time.Now()).Validate(op)
+}
+
+func (g *lifecycleGuard) Renew(
+	ctx context.Context,
+	orderID string,
+	years, expDate int,
+	purchasePrivacy, autoRenew bool,
+	invoiceOption InvoiceOption,
+	discountAmount float64,
+	purchasePremiumDNS bool,
+) (*ActionResponse, error) {
+	if err := g.check(ctx, orderID, OperationRenew); err != nil {
+		return nil, err
+	}
+	return g.Domain.Renew(ctx, orderID, years, expDate, purchasePrivacy, autoRenew, invoiceOption, discountAmount,
+		purchasePremiumDNS)
+}
+
+func (g *lifecycleGuard) Restore(ctx context.Context, orderID string, invoiceOption InvoiceOption) (*ActionResponse, error) {
+	if err := g.check(ctx, orderID, OperationRestore); err != nil {
+		return nil, err
+	}
+	return g.Domain.Restore(ctx, orderID, invoiceOption)
+}
+
+func (g *lifecycleGuard) Delete(ctx context.Context, orderID string) (*DeleteResponse, error) {
+	if err := g.check(ctx, orderID, OperationDelete); err != nil {
+		return nil, err
+	}
+	return g.Domain.Delete(ctx, orderID)
+}
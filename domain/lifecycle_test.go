@@ -0,0 +1,142 @@
+package domain_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderLifecycleOf(t *testing.T) {
+	srv, d := newFake(t)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		order   rctest.Order
+		state   domain.OrderState
+		allowed []domain.OrderOperation
+	}{
+		{
+			name:    "active",
+			order:   rctest.Order{DomainName: "active.com"},
+			state:   domain.OrderActive,
+			allowed: []domain.OrderOperation{domain.OperationRenew, domain.OperationTransferOut},
+		},
+		{
+			name:    "add grace",
+			order:   rctest.Order{DomainName: "new.com", AllowDeletion: true, TheftProtection: true},
+			state:   domain.OrderActive,
+			allowed: []domain.OrderOperation{domain.OperationRenew, domain.OperationDelete},
+		},
+		{
+			name:    "expired in grace",
+			order:   rctest.Order{DomainName: "grace.com", EndTime: now.AddDate(0, 0, -10)},
+			state:   domain.OrderExpiredGrace,
+			allowed: []domain.OrderOperation{domain.OperationRenew},
+		},
+		{
+			name:    "expired in redemption",
+			order:   rctest.Order{DomainName: "late.com", EndTime: now.AddDate(0, 0, -40)},
+			state:   domain.OrderRedemption,
+			allowed: []domain.OrderOperation{domain.OperationRestore},
+		},
+		{
+			name:    "uncatalogued tld in grace",
+			order:   rctest.Order{DomainName: "grace.io", EndTime: now.AddDate(0, 0, -10)},
+			state:   domain.OrderExpiredGrace,
+			allowed: []domain.OrderOperation{domain.OperationRenew},
+		},
+		{
+			name:    "uncatalogued tld in redemption",
+			order:   rctest.Order{DomainName: "late.app", EndTime: now.AddDate(0, 0, -40)},
+			state:   domain.OrderRedemption,
+			allowed: []domain.OrderOperation{domain.OperationRestore},
+		},
+		{
+			name:  "expired past restore",
+			order: rctest.Order{DomainName: "gone.com", EndTime: now.AddDate(0, 0, -70)},
+			state: domain.OrderPendingDelete,
+		},
+		{
+			name:    "restorable",
+			order:   rctest.Order{DomainName: "restorable.com", Status: "Pending Delete Restorable"},
+			state:   domain.OrderRedemption,
+			allowed: []domain.OrderOperation{domain.OperationRestore},
+		},
+		{
+			name:    "suspended",
+			order:   rctest.Order{DomainName: "suspended.com", Status: "Suspended"},
+			state:   domain.OrderSuspended,
+			allowed: []domain.OrderOperation{domain.OperationRenew},
+		},
+		{
+			name:  "transfer in",
+			order: rctest.Order{DomainName: "incoming.com", Status: "InActive"},
+			state: domain.OrderTransferIn,
+		},
+		{
+			name:  "transfer out",
+			order: rctest.Order{DomainName: "outgoing.com", DomainStatus: []string{"pendingTransfer"}},
+			state: domain.OrderTransferOut,
+		},
+		{
+			name:  "deleted",
+			order: rctest.Order{DomainName: "deleted.com", Status: "Deleted"},
+			state: domain.OrderDeleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderID := srv.AddOrder(tt.order)
+			detail, err := d.GetRegistrationOrderDetails(context.Background(), orderID, domain.LifecycleDetailOptions)
+			require.NoError(t, err)
+
+			lifecycle := domain.OrderLifecycleOf(detail, now)
+			require.Equal(t, tt.state, lifecycle.State)
+			for _, op := range []domain.OrderOperation{
+				domain.OperationRenew, domain.OperationRestore, domain.OperationDelete, domain.OperationTransferOut,
+			} {
+				err := lifecycle.Validate(op)
+				if slices.Contains(tt.allowed, op) {
+					require.NoError(t, err, op)
+				} else {
+					require.ErrorIs(t, err, core.ErrValidation, op)
+				}
+			}
+		})
+	}
+}
+
+func TestWithLifecycleChecks(t *testing.T) {
+	srv, d := newFake(t)
+	d = domain.WithLifecycleChecks(d)
+	ctx := context.Background()
+
+	orderID := srv.AddOrder(rctest.Order{DomainName: "example.com"})
+	_, err := d.Delete(ctx, orderID)
+	require.ErrorIs(t, err, core.ErrValidation)
+	require.ErrorContains(t, err, "deletion is not allowed")
+	_, err = d.Restore(ctx, orderID, domain.InvoiceNo)
+	require.ErrorIs(t, err, core.ErrValidation)
+	require.ErrorContains(t, err, "cannot restore order "+orderID+" in state active")
+	for _, call := range srv.Calls() {
+		require.Contains(t, call.Path, "details")
+	}
+
+	o, _ := srv.Order(orderID)
+	_, err = d.Renew(ctx, orderID, 1, int(o.EndTime.Unix()), false, false, domain.InvoiceNo, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, "domains/renew", lastCall(t, srv).Path)
+
+	srv.UpdateOrder(orderID, func(o *rctest.Order) { o.Status = "Pending Delete Restorable" })
+	_, err = d.Renew(ctx, orderID, 1, int(o.EndTime.Unix()), false, false, domain.InvoiceNo, 0, false)
+	require.ErrorIs(t, err, core.ErrValidation)
+	_, err = d.Restore(ctx, orderID, domain.InvoiceNo)
+	require.NoError(t, err)
+}
@@ -0,0 +1,276 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// NameServerConfig is the desired delegation of a domain.
+type NameServerConfig struct {
+	// NameServers is the ordered list of name servers, it must not be empty.
+	NameServers []string
+	// ChildNameServers maps the glue hosts under the domain to their IP addresses. When nil the glue
+	// records are left untouched, otherwise hosts missing from it are deleted.
+	ChildNameServers map[string][]string
+}
+
+// NameServerAction is the API call made by a reconciliation step.
+type NameServerAction string
+
+// Const for name server actions.
+const (
+	NSAddChild       NameServerAction = "add-cns"
+	NSRenameChild    NameServerAction = "modify-cns-name"
+	NSModifyChildIP  NameServerAction = "modify-cns-ip"
+	NSDeleteChildIPs NameServerAction = "delete-cns-ip"
+	NSModify         NameServerAction = "modify-ns"
+)
+
+// NameServerStep is a single call of a reconciliation.
+type NameServerStep struct {
+	Action NameServerAction
+	// Host is the child name server of the child actions, NewHost its new name when renamed.
+	Host    string
+	NewHost string
+	// IPs are the addresses added or deleted.
+	IPs          []string
+	OldIP, NewIP string
+	NameServers  []string
+}
+
+func (s NameServerStep) String() string {
+	switch s.Action {
+	case NSAddChild:
+		return fmt.Sprintf("add %s to child name server %s", strings.Join(s.IPs, ", "), s.Host)
+	case NSRenameChild:
+		return fmt.Sprintf("rename child name server %s to %s", s.Host, s.NewHost)
+	case NSModifyChildIP:
+		return fmt.Sprintf("change ip %s of child name server %s to %s", s.OldIP, s.Host, s.NewIP)
+	case NSDeleteChildIPs:
+		return fmt.Sprintf("delete %s from child name server %s", strings.Join(s.IPs, ", "), s.Host)
+	case NSModify:
+		return "set name servers to " + strings.Join(s.NameServers, ", ")
+	}
+	return string(s.Action)
+}
+
+// NameServerPlan lists the steps reconciling an order with a NameServerConfig, in the order they
+// are applied. Applied counts the steps that succeeded.
+type NameServerPlan struct {
+	OrderID    string
+	DomainName string
+	Steps      []NameServerStep
+	Applied    int
+}
+
+// nameServerDetailOptions are the details options holding the current delegation.
+var nameServerDetailOptions = []DetailOption{DetailOrderDetails, DetailNameServers, DetailChildNameServers}
+
+// ReconcileNameServers brings the name servers and glue records of an order to the desired
+// configuration with the fewest calls. Glue is created or renamed before the domain is delegated
+// to it and deleted only after the delegation moved away, and the order always keeps at least one
+// name server. With dryRun the plan is returned without applying it.
+//
+// When a step fails the plan is returned with the error, Applied tells how far it got.
+func ReconcileNameServers(
+	ctx context.Context,
+	d Domain,
+	orderID string,
+	desired NameServerConfig,
+	dryRun bool,
+) (*NameServerPlan, error) {
+	detail, err := d.GetRegistrationOrderDetails(ctx, orderID, nameServerDetailOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := planNameServers(detail, desired)
+	if err != nil || dryRun {
+		return plan, err
+	}
+
+	for i, step := range plan.Steps {
+		if err := applyNameServerStep(ctx, d, orderID, step); err != nil {
+			return plan, fmt.Errorf("step %d, %s: %w", i+1, step, err)
+		}
+		plan.Applied++
+	}
+	return plan, nil
+}
+
+func planNameServers(detail *OrderDetail, desired NameServerConfig) (*NameServerPlan, error) {
+	plan := &NameServerPlan{OrderID: detail.OrderID, DomainName: detail.DomainName}
+	zone, err := normalizeHost(detail.DomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	wantNS, err := normalizeHosts(desired.NameServers)
+	if err != nil {
+		return nil, err
+	}
+	if len(wantNS) == 0 {
+		return nil, fmt.Errorf("%w: at least one name server is required", core.ErrValidation)
+	}
+
+	haveGlue, err := normalizeGlue(detail.CNS)
+	if err != nil {
+		return nil, err
+	}
+	wantGlue := haveGlue
+	if desired.ChildNameServers != nil {
+		if wantGlue, err = normalizeGlue(desired.ChildNameServers); err != nil {
+			return nil, err
+		}
+	}
+	for host, ips := range wantGlue {
+		if !strings.HasSuffix(host, "."+zone) {
+			return nil, fmt.Errorf("%w: child name server %s is not under %s", core.ErrValidation, host, zone)
+		}
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("%w: child name server %s has no ip address", core.ErrValidation, host)
+		}
+	}
+	for _, ns := range wantNS {
+		if _, ok := wantGlue[ns]; !ok && strings.HasSuffix(ns, "."+zone) {
+			return nil, fmt.Errorf("%w: name server %s requires a child name server", core.ErrValidation, ns)
+		}
+	}
+
+	var added, removed []string
+	for _, host := range slices.Sorted(maps.Keys(wantGlue)) {
+		if _, ok := haveGlue[host]; !ok {
+			added = append(added, host)
+		}
+	}
+	for _, host := range slices.Sorted(maps.Keys(haveGlue)) {
+		if _, ok := wantGlue[host]; !ok {
+			removed = append(removed, host)
+		}
+	}
+
+	// A host replaced by one with the same addresses is renamed, which also moves the delegation.
+	for _, host := range added {
+		i := slices.IndexFunc(removed, func(old string) bool { return slices.Equal(haveGlue[old], wantGlue[host]) })
+		if i < 0 {
+			plan.Steps = append(plan.Steps, NameServerStep{Action: NSAddChild, Host: host, IPs: wantGlue[host]})
+			continue
+		}
+		plan.Steps = append(plan.Steps, NameServerStep{Action: NSRenameChild, Host: removed[i], NewHost: host})
+		removed = slices.Delete(removed, i, i+1)
+	}
+
+	// Addresses are added or changed before any is deleted so a host never loses all of them.
+	var deletes []NameServerStep
+	for _, host := range slices.Sorted(maps.Keys(wantGlue)) {
+		have, ok := haveGlue[host]
+		if !ok {
+			continue
+		}
+		want := wantGlue[host]
+		gone := slices.DeleteFunc(slices.Clone(have), func(ip string) bool { return slices.Contains(want, ip) })
+		fresh := slices.DeleteFunc(slices.Clone(want), func(ip string) bool { return slices.Contains(have, ip) })
+		n := min(len(gone), len(fresh))
+		for i := range n {
+			plan.Steps = append(plan.Steps, NameServerStep{
+				Action: NSModifyChildIP, Host: host, OldIP: gone[i], NewIP: fresh[i],
+			})
+		}
+		if len(fresh) > n {
+			plan.Steps = append(plan.Steps, NameServerStep{Action: NSAddChild, Host: host, IPs: fresh[n:]})
+		}
+		if len(gone) > n {
+			deletes = append(deletes, NameServerStep{Action: NSDeleteChildIPs, Host: host, IPs: gone[n:]})
+		}
+	}
+	plan.Steps = append(plan.Steps, deletes...)
+
+	// Renames already moved the delegation away from the old host names.
+	haveNS, err := normalizeHosts(detail.NameServers())
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range plan.Steps {
+		if step.Action == NSRenameChild {
+			if i := slices.Index(haveNS, step.Host); i >= 0 {
+				haveNS[i] = step.NewHost
+			}
+		}
+	}
+	if !slices.Equal(haveNS, wantNS) {
+		plan.Steps = append(plan.Steps, NameServerStep{Action: NSModify, NameServers: wantNS})
+	}
+
+	for _, host := range removed {
+		plan.Steps = append(plan.Steps, NameServerStep{Action: NSDeleteChildIPs, Host: host, IPs: haveGlue[host]})
+	}
+
+	return plan, nil
+}
+
+func applyNameServerStep(ctx context.Context, d Domain, orderID string, step NameServerStep) error {
+	var err error
+	switch step.Action {
+	case NSAddChild:
+		_, err = d.AddChildNameServer(ctx, orderID, step.Host, step.IPs)
+	case NSRenameChild:
+		_, err = d.ModifyChildNameServerHostName(ctx, orderID, step.Host, step.NewHost)
+	case NSModifyChildIP:
+		_, err = d.ModifyChildNameServerIPAddress(ctx, orderID, step.Host, step.OldIP, step.NewIP)
+	case NSDeleteChildIPs:
+		_, err = d.DeletingChildNameServerIPAddress(ctx, orderID, step.Host, step.IPs)
+	case NSModify:
+		_, err = d.ModifyNameServers(ctx, orderID, step.NameServers)
+	default:
+		err = errors.New("unknown action")
+	}
+	return err
+}
+
+func normalizeHost(host string) (string, error) {
+	return ToASCII(strings.TrimSuffix(strings.TrimSpace(host), "."))
+}
+
+func normalizeHosts(hosts []string) ([]string, error) {
+	ret := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		host, err := normalizeHost(host)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(ret, host) {
+			ret = append(ret, host)
+		}
+	}
+	return ret, nil
+}
+
+// normalizeGlue normalizes the host names and sorts the IP addresses of glue records.
+func normalizeGlue(glue map[string][]string) (map[string][]string, error) {
+	ret := make(map[string][]string, len(glue))
+	for host, ips := range glue {
+		host, err := normalizeHost(host)
+		if err != nil {
+			return nil, err
+		}
+		ret[host] = make([]string, 0, len(ips))
+		for _, ip := range ips {
+			addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+			if err != nil {
+				return nil, fmt.Errorf("%w: child name server %s: %w", core.ErrValidation, host, err)
+			}
+			if !slices.Contains(ret[host], addr.String()) {
+				ret[host] = append(ret[host], addr.String())
+			}
+		}
+		slices.Sort(ret[host])
+	}
+	return ret, nil
+}
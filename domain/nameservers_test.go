@@ -0,0 +1,107 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileNameServers(t *testing.T) {
+	srv, d := newFake(t)
+	ctx := context.Background()
+	orderID := srv.AddOrder(rctest.Order{
+		DomainName:  "example.com",
+		NameServers: []string{"ns1.example.com", "ns2.example.com"},
+		ChildNameServers: map[string][]string{
+			"ns1.example.com": {"192.0.2.1"},
+			"ns2.example.com": {"192.0.2.2", "192.0.2.3"},
+		},
+	})
+	desired := domain.NameServerConfig{
+		NameServers: []string{"dns1.example.com", "NS3.example.com.", "ns.provider.net"},
+		ChildNameServers: map[string][]string{
+			"dns1.example.com": {"192.0.2.1"},
+			"ns3.example.com":  {"2001:db8::1", "198.51.100.1"},
+		},
+	}
+
+	plan, err := domain.ReconcileNameServers(ctx, d, orderID, desired, true)
+	require.NoError(t, err)
+	var steps []string
+	for _, step := range plan.Steps {
+		steps = append(steps, step.String())
+	}
+	require.Equal(t, []string{
+		"rename child name server ns1.example.com to dns1.example.com",
+		"add 198.51.100.1, 2001:db8::1 to child name server ns3.example.com",
+		"set name servers to dns1.example.com, ns3.example.com, ns.provider.net",
+		"delete 192.0.2.2, 192.0.2.3 from child name server ns2.example.com",
+	}, steps)
+	require.Zero(t, plan.Applied)
+	for _, call := range srv.Calls() {
+		require.Equal(t, "domains/details", call.Path)
+	}
+
+	plan, err = domain.ReconcileNameServers(ctx, d, orderID, desired, false)
+	require.NoError(t, err)
+	require.Equal(t, 4, plan.Applied)
+	o, _ := srv.Order(orderID)
+	require.Equal(t, []string{"dns1.example.com", "ns3.example.com", "ns.provider.net"}, o.NameServers)
+	require.Equal(t, map[string][]string{
+		"dns1.example.com": {"192.0.2.1"},
+		"ns3.example.com":  {"198.51.100.1", "2001:db8::1"},
+	}, o.ChildNameServers)
+
+	plan, err = domain.ReconcileNameServers(ctx, d, orderID, desired, false)
+	require.NoError(t, err)
+	require.Empty(t, plan.Steps)
+}
+
+func TestReconcileNameServersIPs(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{
+		DomainName:       "example.com",
+		NameServers:      []string{"ns1.example.com", "ns2.example.com"},
+		ChildNameServers: map[string][]string{"ns1.example.com": {"192.0.2.1", "192.0.2.2"}, "ns2.example.com": {"192.0.2.3"}},
+	})
+
+	plan, err := domain.ReconcileNameServers(context.Background(), d, orderID, domain.NameServerConfig{
+		NameServers:      []string{"ns1.example.com", "ns2.example.com"},
+		ChildNameServers: map[string][]string{"ns1.example.com": {"192.0.2.9"}, "ns2.example.com": {"192.0.2.3", "192.0.2.4"}},
+	}, false)
+	require.NoError(t, err)
+	require.Equal(t, []domain.NameServerStep{
+		{Action: domain.NSModifyChildIP, Host: "ns1.example.com", OldIP: "192.0.2.1", NewIP: "192.0.2.9"},
+		{Action: domain.NSAddChild, Host: "ns2.example.com", IPs: []string{"192.0.2.4"}},
+		{Action: domain.NSDeleteChildIPs, Host: "ns1.example.com", IPs: []string{"192.0.2.2"}},
+	}, plan.Steps)
+	o, _ := srv.Order(orderID)
+	require.Equal(t, []string{"192.0.2.9"}, o.ChildNameServers["ns1.example.com"])
+	require.Equal(t, []string{"192.0.2.3", "192.0.2.4"}, o.ChildNameServers["ns2.example.com"])
+}
+
+func TestReconcileNameServersInvalid(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{
+		DomainName:       "example.com",
+		NameServers:      []string{"ns1.example.com"},
+		ChildNameServers: map[string][]string{"ns1.example.com": {"192.0.2.1"}},
+	})
+
+	for _, desired := range []domain.NameServerConfig{
+		{},
+		{NameServers: []string{"ns2.example.com"}},
+		{NameServers: []string{"ns1.example.com"}, ChildNameServers: map[string][]string{}},
+		{NameServers: []string{"ns.other.net"}, ChildNameServers: map[string][]string{"ns.other.net": {"192.0.2.1"}}},
+		{NameServers: []string{"ns1.example.com"}, ChildNameServers: map[string][]string{"ns1.example.com": {}}},
+		{NameServers: []string{"ns1.example.com"}, ChildNameServers: map[string][]string{"ns1.example.com": {"bad"}}},
+	} {
+		_, err := domain.ReconcileNameServers(context.Background(), d, orderID, desired, false)
+		require.ErrorIs(t, err, core.ErrValidation, desired)
+	}
+	require.Len(t, srv.Calls(), 6)
+}
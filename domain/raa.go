@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// RAAVerificationPeriod is the time a registrant has to verify their email address before the
+// domain is suspended.
+const RAAVerificationPeriod = 15 * 24 * time.Hour
+
+// RAAVerification is the registrant verification state of an order. Deadline is set while the
+// verification is pending.
+type RAAVerification struct {
+	OrderID    string                `json:"order_id"`
+	DomainName string                `json:"domain_name"`
+	CustomerID string                `json:"customer_id"`
+	Status     RAAVerificationStatus `json:"status"`
+	StartTime  time.Time             `json:"start_time"`
+	Deadline   time.Time             `json:"deadline"`
+}
+
+func raaVerificationOf(detail *OrderDetail) *RAAVerification {
+	v := &RAAVerification{
+		OrderID:    detail.OrderID,
+		DomainName: detail.DomainName,
+		CustomerID: detail.CustomerID,
+		Status:     detail.RaaVerificationStatus,
+		StartTime:  detail.RaaVerificationStartTime.ToTime(),
+	}
+	if v.Status == "" {
+		v.Status = RAANotApplicable
+	}
+	if v.Status == RAAPending && !v.StartTime.IsZero() {
+		v.Deadline = v.StartTime.Add(RAAVerificationPeriod)
+	}
+	return v
+}
+
+// RAASweepOptions selects the orders checked by SweepRAAVerifications.
+type RAASweepOptions struct {
+	// Within reports the pending verifications whose deadline is within this duration from now,
+	// including those past it. Every pending verification is reported when zero.
+	Within time.Duration
+	// Criteria filters the orders searched, the active orders when empty.
+	Criteria OrderCriteria
+	// Resend resends the verification mail of every reported order.
+	Resend bool
+	// Concurrency is the number of orders checked in parallel, DefaultBulkConcurrency when zero.
+	Concurrency int
+}
+
+// RAASweepResult is a pending verification found by SweepRAAVerifications. ResendError is set when
+// the resend was requested and failed.
+type RAASweepResult struct {
+	RAAVerification
+	Resent      bool  `json:"resent"`
+	ResendError error `json:"-"`
+}
+
+// SweepRAAVerifications finds the orders with a pending registrant verification approaching the
+// suspension deadline, sorted by deadline, and optionally resends their verification mail. It
+// fails if the verification state of any order cannot be read, a failed resend is reported in its
+// result instead.
+func SweepRAAVerifications(ctx context.Context, d Domain, opts RAASweepOptions) ([]RAASweepResult, error) {
+	criteria := opts.Criteria
+	if len(criteria.Statuses) == 0 {
+		criteria.Statuses = []core.EntityStatus{core.StatusActive}
+	}
+	var orderIDs []string
+	for order, err := range d.SearchAllOrders(ctx, criteria, 100) {
+		if err != nil {
+			return nil, err
+		}
+		orderIDs = append(orderIDs, order.OrderID)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	cutoff := time.Now().Add(opts.Within)
+	mu := sync.Mutex{}
+	var (
+		results []RAASweepResult
+		errs    []error
+	)
+
+	forEachConcurrently(orderIDs, concurrency, func(orderID string) {
+		v, err := d.GetRAAVerification(ctx, orderID)
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, fmt.Errorf("order %s: %w", orderID, err))
+			return
+		}
+		if v.Status != RAAPending || (opts.Within > 0 && v.Deadline.After(cutoff)) {
+			return
+		}
+
+		result := RAASweepResult{RAAVerification: *v}
+		if opts.Resend {
+			_, result.ResendError = d.ResendRAAVerification(ctx, orderID)
+			result.Resent = result.ResendError == nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, result)
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(results, func(a, b RAASweepResult) int {
+		if c := a.Deadline.Compare(b.Deadline); c != 0 {
+			return c
+		}
+		return strings.Compare(a.DomainName, b.DomainName)
+	})
+	return results, nil
+}
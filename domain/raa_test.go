@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRAAVerification(t *testing.T) {
+	srv, d := newFake(t)
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	pending := srv.AddOrder(rctest.Order{
+		DomainName:               "pending.com",
+		RAAVerificationStatus:    "Pending",
+		RAAVerificationStartTime: start,
+	})
+	verified := srv.AddOrder(rctest.Order{DomainName: "verified.com", RAAVerificationStatus: "Verified"})
+
+	v, err := d.GetRAAVerification(ctx, pending)
+	require.NoError(t, err)
+	require.Equal(t, domain.RAAPending, v.Status)
+	require.Equal(t, start, v.StartTime)
+	require.Equal(t, start.Add(15*24*time.Hour), v.Deadline)
+
+	v, err = d.GetRAAVerification(ctx, verified)
+	require.NoError(t, err)
+	require.Equal(t, domain.RAAVerified, v.Status)
+	require.True(t, v.Deadline.IsZero())
+
+	res, err := d.ResendRAAVerification(ctx, pending)
+	require.NoError(t, err)
+	require.Equal(t, "Success", res.Status)
+	require.Equal(t, "domains/raa/resend-verification", lastCall(t, srv).Path)
+
+	_, err = d.ResendRAAVerification(ctx, verified)
+	require.Error(t, err)
+}
+
+func TestSweepRAAVerifications(t *testing.T) {
+	srv, d := newFake(t)
+	now := time.Now()
+	addPending := func(name string, started time.Duration) string {
+		return srv.AddOrder(rctest.Order{
+			DomainName:               name,
+			RAAVerificationStatus:    "Pending",
+			RAAVerificationStartTime: now.Add(-started),
+		})
+	}
+	urgent := addPending("urgent.com", 14*24*time.Hour)
+	overdue := addPending("overdue.com", 16*24*time.Hour)
+	addPending("recent.com", time.Hour)
+	srv.AddOrder(rctest.Order{DomainName: "verified.com", RAAVerificationStatus: "Verified"})
+	srv.AddOrder(rctest.Order{
+		DomainName:               "suspended.com",
+		Status:                   "Suspended",
+		RAAVerificationStatus:    "Pending",
+		RAAVerificationStartTime: now.Add(-20 * 24 * time.Hour),
+	})
+
+	results, err := domain.SweepRAAVerifications(context.Background(), d, domain.RAASweepOptions{
+		Within: 3 * 24 * time.Hour,
+		Resend: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, overdue, results[0].OrderID)
+	require.Equal(t, urgent, results[1].OrderID)
+	for _, result := range results {
+		require.True(t, result.Resent)
+		require.NoError(t, result.ResendError)
+	}
+
+	var resent []string
+	for _, call := range srv.Calls() {
+		if call.Path == "domains/raa/resend-verification" {
+			resent = append(resent, call.Params.Get("order-id"))
+		}
+	}
+	require.ElementsMatch(t, []string{urgent, overdue}, resent)
+
+	results, err = domain.SweepRAAVerifications(context.Background(), d, domain.RAASweepOptions{})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, "recent.com", results[2].DomainName)
+	require.False(t, results[2].Resent)
+
+	results, err = domain.SweepRAAVerifications(context.Background(), d, domain.RAASweepOptions{
+		Criteria: domain.OrderCriteria{Statuses: []core.EntityStatus{core.StatusSuspended}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "suspended.com", results[0].DomainName)
+}
@@ -0,0 +1,291 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/pricing"
+)
+
+// Const for the customer pricing entries of a renewal. Privacy protection is priced per year
+// under its own product key.
+const (
+	priceActionRenew   = "renewdomain"
+	privacyProductKey  = "privacyprotection"
+	priceActionPrivacy = "renewprivacyprotection"
+)
+
+// RenewalPlanner finds the domain orders expiring within a window, prices their renewal and renews
+// a selection of them.
+type RenewalPlanner struct {
+	domain  Domain
+	pricing pricing.Pricing
+
+	// Years is the renewal term, 1 when zero.
+	Years int
+	// InvoiceOption is sent with every renewal, InvoicePay when empty.
+	InvoiceOption InvoiceOption
+	// IncludeAutoRenew plans orders with auto-renew enabled too. They are skipped by default since
+	// they are renewed by the platform.
+	IncludeAutoRenew bool
+	// Concurrency is the number of order detail and pricing requests in flight, DefaultBulkConcurrency
+	// when zero.
+	Concurrency int
+}
+
+// NewRenewalPlanner returns a planner renewing the orders of d at the customer prices of p, for one
+// year with InvoicePay unless configured otherwise.
+func NewRenewalPlanner(d Domain, p pricing.Pricing) *RenewalPlanner {
+	return &RenewalPlanner{domain: d, pricing: p}
+}
+
+// RenewalCandidate is an expiring order. Cost covers all the Years of the term, and for a privacy
+// protected order the renewal of its privacy protection too. SkipReason is set when the order is
+// not to be renewed.
+type RenewalCandidate struct {
+	OrderID          string         `json:"order_id"`
+	DomainName       string         `json:"domain_name"`
+	CustomerID       string         `json:"customer_id"`
+	ProductKey       core.DomainKey `json:"product_key"`
+	ExpiryTime       time.Time      `json:"expiry_time"`
+	AutoRenew        bool           `json:"auto_renew"`
+	PrivacyProtected bool           `json:"privacy_protected"`
+	Years            int            `json:"years"`
+	Cost             float64        `json:"cost"`
+	SkipReason       string         `json:"skip_reason,omitempty"`
+}
+
+// RenewalPlan lists the orders expiring within [From, To], sorted by expiry time.
+type RenewalPlan struct {
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Candidates []RenewalCandidate `json:"candidates"`
+}
+
+// ByCustomer groups the candidates by customer ID.
+func (p *RenewalPlan) ByCustomer() map[string][]RenewalCandidate {
+	ret := map[string][]RenewalCandidate{}
+	for _, c := range p.Candidates {
+		ret[c.CustomerID] = append(ret[c.CustomerID], c)
+	}
+	return ret
+}
+
+// Total returns the cost of renewing every candidate that is not skipped.
+func (p *RenewalPlan) Total() float64 {
+	var total float64
+	for _, c := range p.Candidates {
+		if c.SkipReason == "" {
+			total += c.Cost
+		}
+	}
+	return total
+}
+
+// Plan lists the active orders expiring within the given duration from now, with their renewal
+// cost for the whole term from the per-year customer pricing.
+func (p *RenewalPlanner) Plan(ctx context.Context, within time.Duration) (*RenewalPlan, error) {
+	now := time.Now()
+	plan := &RenewalPlan{From: now, To: now.Add(within)}
+
+	var candidates []RenewalCandidate
+	for order, err := range p.domain.SearchAllOrders(ctx, OrderCriteria{
+		Statuses:        []core.EntityStatus{core.StatusActive},
+		TimeExpiryStart: plan.From,
+		TimeExpiryEnd:   plan.To,
+	}, 100) {
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, RenewalCandidate{
+			OrderID:    order.OrderID,
+			DomainName: order.DomainName,
+			CustomerID: order.CustomerID,
+			ProductKey: order.ProductKey,
+			ExpiryTime: order.ExpiryTime.ToTime(),
+			AutoRenew:  order.AutoRenew.ToBool(),
+			Years:      max(p.Years, 1),
+		})
+	}
+
+	prices := map[string]pricing.CustomerPrice{}
+	for _, c := range candidates {
+		prices[c.CustomerID] = nil
+	}
+
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	mu := sync.Mutex{}
+	var errs []error
+
+	forEachConcurrently(slices.Sorted(maps.Keys(prices)), concurrency, func(customerID string) {
+		price, err := p.pricing.GettingCustomerPricing(ctx, customerID)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pricing of customer %s: %w", customerID, err))
+			return
+		}
+		prices[customerID] = price
+	})
+	forEachConcurrently(indexes(candidates), concurrency, func(i int) {
+		detail, err := p.domain.GetRegistrationOrderDetails(ctx, candidates[i].OrderID, []DetailOption{DetailOrderDetails})
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("details of order %s: %w", candidates[i].OrderID, err))
+			return
+		}
+		candidates[i].ExpiryTime = detail.EndTime.ToTime()
+		candidates[i].AutoRenew = detail.Recurring.ToBool()
+		candidates[i].PrivacyProtected = detail.IsPrivacyProtected.ToBool()
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		c := &candidates[i]
+		years := fmt.Sprint(c.Years)
+		cost, ok := prices[c.CustomerID][string(c.ProductKey)][priceActionRenew][years]
+		privacyCost, privacyOK := prices[c.CustomerID][privacyProductKey][priceActionPrivacy][years]
+		switch {
+		case c.AutoRenew && !p.IncludeAutoRenew:
+			c.SkipReason = "auto-renew enabled"
+		case !ok:
+			c.SkipReason = fmt.Sprintf("no %d year renewal price for %s", c.Years, c.ProductKey)
+		case c.PrivacyProtected && !privacyOK:
+			c.SkipReason = fmt.Sprintf("no %d year privacy protection price", c.Years)
+		}
+		if c.PrivacyProtected {
+			cost += privacyCost
+		}
+		c.Cost = cost * float64(c.Years)
+	}
+	slices.SortStableFunc(candidates, func(a, b RenewalCandidate) int {
+		return a.ExpiryTime.Compare(b.ExpiryTime)
+	})
+	plan.Candidates = candidates
+
+	return plan, nil
+}
+
+// RenewalOptions selects what Execute renews.
+type RenewalOptions struct {
+	// OrderIDs restricts the renewals to these orders, all candidates when empty.
+	OrderIDs []string
+	// DryRun reports what would be renewed without renewing anything.
+	DryRun bool
+	// SpendingCap is the most the run may spend, no limit when zero. Orders are renewed by expiry
+	// time and those that would exceed the cap are skipped.
+	SpendingCap float64
+}
+
+type RenewalOutcome string
+
+// Const for renewal outcomes.
+const (
+	RenewalRenewed RenewalOutcome = "renewed"
+	RenewalPlanned RenewalOutcome = "planned"
+	RenewalSkipped RenewalOutcome = "skipped"
+	RenewalFailed  RenewalOutcome = "failed"
+)
+
+// RenewalResult is the outcome of one candidate. Planned is the outcome of a dry run.
+type RenewalResult struct {
+	RenewalCandidate
+	Outcome   RenewalOutcome `json:"outcome"`
+	InvoiceID string         `json:"invoice_id,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// RenewalReport is the machine-readable outcome of Execute. Spent includes the planned renewals
+// of a dry run.
+type RenewalReport struct {
+	DryRun      bool            `json:"dry_run"`
+	SpendingCap float64         `json:"spending_cap,omitempty"`
+	Spent       float64         `json:"spent"`
+	Results     []RenewalResult `json:"results"`
+}
+
+// Failed returns the results of the failed renewals.
+func (r *RenewalReport) Failed() []RenewalResult {
+	return r.withOutcome(RenewalFailed)
+}
+
+// Renewed returns the results of the successful renewals.
+func (r *RenewalReport) Renewed() []RenewalResult {
+	return r.withOutcome(RenewalRenewed)
+}
+
+func (r *RenewalReport) withOutcome(outcome RenewalOutcome) []RenewalResult {
+	var ret []RenewalResult
+	for _, result := range r.Results {
+		if result.Outcome == outcome {
+			ret = append(ret, result)
+		}
+	}
+	return ret
+}
+
+// Execute renews the selected candidates of the plan one at a time. A failed renewal does not stop
+// the run, the report lists every candidate of the plan.
+func (p *RenewalPlanner) Execute(ctx context.Context, plan *RenewalPlan, opts RenewalOptions) (*RenewalReport, error) {
+	if plan == nil {
+		return nil, errors.New("plan must not be nil")
+	}
+
+	invoiceOption := p.InvoiceOption
+	if invoiceOption == "" {
+		invoiceOption = InvoicePay
+	}
+
+	report := &RenewalReport{DryRun: opts.DryRun, SpendingCap: opts.SpendingCap}
+	for _, c := range plan.Candidates {
+		result := RenewalResult{RenewalCandidate: c, Outcome: RenewalSkipped}
+		switch {
+		case len(opts.OrderIDs) > 0 && !slices.Contains(opts.OrderIDs, c.OrderID):
+			result.SkipReason = "not selected"
+		case c.SkipReason != "":
+		case opts.SpendingCap > 0 && report.Spent+c.Cost > opts.SpendingCap:
+			result.SkipReason = "spending cap reached"
+		case opts.DryRun:
+			result.Outcome = RenewalPlanned
+			report.Spent += c.Cost
+		default:
+			res, err := p.domain.Renew(ctx, c.OrderID, c.Years, int(c.ExpiryTime.Unix()), c.PrivacyProtected,
+				c.AutoRenew, invoiceOption, 0, false)
+			if err != nil {
+				result.Outcome = RenewalFailed
+				result.Error = err.Error()
+				break
+			}
+			result.Outcome = RenewalRenewed
+			result.InvoiceID = res.InvoiceID
+			report.Spent += c.Cost
+		}
+		report.Results = append(report.Results, result)
+
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func indexes[T any](s []T) []int {
+	ret := make([]int, len(s))
+	for i := range ret {
+		ret[i] = i
+	}
+	return ret
+}
@@ -0,0 +1,145 @@
+package domain_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/pricing"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewalPlanner(t *testing.T) {
+	srv, d := newFake(t)
+	alice := srv.AddCustomer(rctest.Customer{Username: "alice@example.com"})
+	bob := srv.AddCustomer(rctest.Customer{Username: "bob@example.com"})
+	inDays := func(days int) time.Time { return time.Now().Add(time.Duration(days) * 24 * time.Hour) }
+
+	soon := srv.AddOrder(rctest.Order{DomainName: "soon.com", CustomerID: alice, EndTime: inDays(5)})
+	later := srv.AddOrder(rctest.Order{DomainName: "later.net", CustomerID: alice, EndTime: inDays(10)})
+	cheap := srv.AddOrder(rctest.Order{DomainName: "cheap.xyz", CustomerID: bob, EndTime: inDays(15)})
+	auto := srv.AddOrder(rctest.Order{DomainName: "auto.org", CustomerID: bob, EndTime: inDays(20), AutoRenew: true})
+	srv.AddOrder(rctest.Order{DomainName: "far.com", CustomerID: bob, EndTime: inDays(60)})
+
+	planner := domain.NewRenewalPlanner(d, pricing.New(core.New(srv.Config(), srv.Client())))
+	plan, err := planner.Plan(context.Background(), 30*24*time.Hour)
+	require.NoError(t, err)
+
+	var orderIDs []string
+	for _, c := range plan.Candidates {
+		orderIDs = append(orderIDs, c.OrderID)
+	}
+	require.Equal(t, []string{soon, later, cheap, auto}, orderIDs)
+	require.Equal(t, "auto-renew enabled", plan.Candidates[3].SkipReason)
+	require.InDelta(t, 9.99+11.99+1.99, plan.Total(), 0.001)
+	require.Len(t, plan.ByCustomer()[alice], 2)
+	require.Len(t, plan.ByCustomer()[bob], 2)
+
+	report, err := planner.Execute(context.Background(), plan, domain.RenewalOptions{DryRun: true, SpendingCap: 15})
+	require.NoError(t, err)
+	outcomes := map[string]domain.RenewalOutcome{}
+	for _, result := range report.Results {
+		outcomes[result.OrderID] = result.Outcome
+	}
+	require.Equal(t, map[string]domain.RenewalOutcome{
+		soon:  domain.RenewalPlanned,
+		later: domain.RenewalSkipped,
+		cheap: domain.RenewalPlanned,
+		auto:  domain.RenewalSkipped,
+	}, outcomes)
+	require.InDelta(t, 11.98, report.Spent, 0.001)
+	order, _ := srv.Order(soon)
+	require.WithinDuration(t, inDays(5), order.EndTime, time.Minute)
+
+	srv.UpdateOrder(later, func(o *rctest.Order) { o.Status = "Deleted" })
+	report, err = planner.Execute(context.Background(), plan, domain.RenewalOptions{OrderIDs: []string{soon, later}})
+	require.NoError(t, err)
+	require.Len(t, report.Renewed(), 1)
+	require.Len(t, report.Failed(), 1)
+	require.Equal(t, later, report.Failed()[0].OrderID)
+	require.InDelta(t, 9.99, report.Spent, 0.001)
+
+	order, _ = srv.Order(soon)
+	require.WithinDuration(t, inDays(5).AddDate(1, 0, 0), order.EndTime, time.Minute)
+
+	encoded, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"outcome":"failed"`)
+	require.Contains(t, string(encoded), `"skip_reason":"not selected"`)
+}
+
+func TestRenewalPlannerMultiYear(t *testing.T) {
+	srv, d := newFake(t)
+	customerID := srv.AddCustomer(rctest.Customer{Username: "alice@example.com"})
+	inDays := func(days int) time.Time { return time.Now().Add(time.Duration(days) * 24 * time.Hour) }
+
+	first := srv.AddOrder(rctest.Order{DomainName: "first.com", CustomerID: customerID, EndTime: inDays(5),
+		PrivacyProtected: true})
+	second := srv.AddOrder(rctest.Order{DomainName: "second.com", CustomerID: customerID, EndTime: inDays(10)})
+
+	planner := domain.NewRenewalPlanner(d, pricing.New(core.New(srv.Config(), srv.Client())))
+	planner.Years = 3
+	plan, err := planner.Plan(context.Background(), 30*24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, plan.Candidates, 2)
+	require.InDelta(t, 3*(9.99+2.99), plan.Candidates[0].Cost, 0.001)
+	require.InDelta(t, 3*9.99, plan.Candidates[1].Cost, 0.001)
+	require.True(t, plan.Candidates[0].PrivacyProtected)
+	require.False(t, plan.Candidates[1].PrivacyProtected)
+
+	// One year of both fits the cap, three years of only one.
+	report, err := planner.Execute(context.Background(), plan, domain.RenewalOptions{SpendingCap: 40})
+	require.NoError(t, err)
+	require.Len(t, report.Renewed(), 1)
+	require.Equal(t, first, report.Renewed()[0].OrderID)
+	require.Equal(t, "spending cap reached", report.Results[1].SkipReason)
+	require.InDelta(t, 3*(9.99+2.99), report.Spent, 0.001)
+
+	call := lastCall(t, srv)
+	require.Equal(t, "domains/renew", call.Path)
+	require.Equal(t, "3", call.Params.Get("years"))
+	require.Equal(t, "true", call.Params.Get("purchase-privacy"))
+
+	order, _ := srv.Order(first)
+	require.WithinDuration(t, inDays(5).AddDate(3, 0, 0), order.EndTime, time.Minute)
+	order, _ = srv.Order(second)
+	require.WithinDuration(t, inDays(10), order.EndTime, time.Minute)
+}
+
+func TestRenewalPlannerPrivacyCost(t *testing.T) {
+	srv, d := newFake(t)
+	customerID := srv.AddCustomer(rctest.Customer{Username: "alice@example.com"})
+	orderID := srv.AddOrder(rctest.Order{
+		DomainName:       "private.com",
+		CustomerID:       customerID,
+		EndTime:          time.Now().Add(5 * 24 * time.Hour),
+		PrivacyProtected: true,
+	})
+
+	planner := domain.NewRenewalPlanner(d, pricing.New(core.New(srv.Config(), srv.Client())))
+	plan, err := planner.Plan(context.Background(), 30*24*time.Hour)
+	require.NoError(t, err)
+	require.InDelta(t, 9.99+2.99, plan.Total(), 0.001)
+
+	// The domain alone fits the cap, its privacy protection does not.
+	report, err := planner.Execute(context.Background(), plan, domain.RenewalOptions{SpendingCap: 11})
+	require.NoError(t, err)
+	require.Empty(t, report.Renewed())
+	require.Equal(t, "spending cap reached", report.Results[0].SkipReason)
+	order, _ := srv.Order(orderID)
+	require.WithinDuration(t, time.Now().Add(5*24*time.Hour), order.EndTime, time.Minute)
+
+	srv.Handle("products/customer-price", func(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+		rctest.WriteJSON(w, map[string]any{"domcno": map[string]any{"renewdomain": map[string]float64{"1": 9.99}}})
+	})
+	plan, err = planner.Plan(context.Background(), 30*24*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, "no 1 year privacy protection price", plan.Candidates[0].SkipReason)
+	require.Zero(t, plan.Total())
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// RegisterRequest holds the parameters of a domain registration.
+type RegisterRequest struct {
+	DomainName         string                `validate:"required,dnsname"`
+	Years              int                   `validate:"min=1,max=10"`
+	NameServers        []string              `validate:"min=1,max=13,dive,dnsname"`
+	CustomerID         string                `validate:"required,number"`
+	RegContactID       string                `validate:"required,number|eq=-1"`
+	AdminContactID     string                `validate:"required,number|eq=-1"`
+	TechContactID      string                `validate:"required,number|eq=-1"`
+	BillingContactID   string                `validate:"required,number|eq=-1"`
+	InvoiceOption      InvoiceOption         `validate:"required,oneof=NoInvoice PayInvoice KeepInvoice OnlyAdd"`
+	PurchasePrivacy    bool                  `validate:"-"`
+	ProtectPrivacy     bool                  `validate:"-"`
+	AutoRenew          bool                  `validate:"-"`
+	Attributes         core.EntityAttributes `validate:"-"`
+	DiscountAmount     float64               `validate:"gte=0"`
+	PurchasePremiumDNS bool                  `validate:"-"`
+	// IDNLanguage is the language code sent for IDNs under TLDs that require one. It defaults to
+	// the language of the name's script, see IDNLanguage.
+	IDNLanguage string `validate:"omitempty,alpha,len=3"`
+}
+
+// TransferRequest holds the parameters of a domain transfer. NameServers are optional, the
+// current ones are kept when empty.
+type TransferRequest struct {
+	DomainName         string                `validate:"required,dnsname"`
+	AuthCode           string                `validate:"omitempty,max=255"`
+	NameServers        []string              `validate:"max=13,dive,dnsname"`
+	CustomerID         string                `validate:"required,number"`
+	RegContactID       string                `validate:"required,number|eq=-1"`
+	AdminContactID     string                `validate:"required,number|eq=-1"`
+	TechContactID      string                `validate:"required,number|eq=-1"`
+	BillingContactID   string                `validate:"required,number|eq=-1"`
+	InvoiceOption      InvoiceOption         `validate:"required,oneof=NoInvoice PayInvoice KeepInvoice OnlyAdd"`
+	PurchasePrivacy    bool                  `validate:"-"`
+	ProtectPrivacy     bool                  `validate:"-"`
+	AutoRenew          bool                  `validate:"-"`
+	Attributes         core.EntityAttributes `validate:"-"`
+	PurchasePremiumDNS bool                  `validate:"-"`
+}
+
+func validateRequest(r any) error {
+	v := validator.New()
+	if err := v.RegisterValidation("dnsname", validateDNSName); err != nil {
+		return err
+	}
+	if err := v.Struct(r); err != nil {
+		return fmt.Errorf("%w: %w", core.ErrValidation, err)
+	}
+	return nil
+}
+
+// dnsLabel matches a lowercase letter-digit-hyphen label, which includes the xn-- A-labels.
+var dnsLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// validateDNSName accepts a fully qualified name in A-label form. Unlike the fqdn tag of the
+// validator it allows hyphens in the TLD, so IDN TLDs such as xn--p1ai pass.
+func validateDNSName(fl validator.FieldLevel) bool {
+	name := strings.TrimSuffix(fl.Field().String(), ".")
+	labels := strings.Split(name, ".")
+	if len(name) > 253 || len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if !dnsLabel.MatchString(label) {
+			return false
+		}
+	}
+	return strings.Trim(labels[len(labels)-1], "0123456789") != ""
+}
+
+// URLValues validates the request and returns the parameters of domains/register. Internationalized
+// domain and name server names are sent as A-labels.
+func (r *RegisterRequest) URLValues() (url.Values, error) {
+	asciiName, err := ToASCII(r.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	nameServers, err := toASCIIAll(r.NameServers)
+	if err != nil {
+		return nil, err
+	}
+	req := *r
+	req.DomainName, req.NameServers = asciiName, nameServers
+	if err := validateRequest(&req); err != nil {
+		return nil, err
+	}
+	r = &req
+
+	attributes := r.Attributes
+	if idnLanguageRequired(asciiName) && (attributes == nil || attributes.Get(IDNLanguageAttr) == "") {
+		language := r.IDNLanguage
+		if language == "" {
+			if language, err = IDNLanguage(asciiName); err != nil {
+				return nil, err
+			}
+		}
+		attributes = withAttribute(attributes, IDNLanguageAttr, strings.ToLower(language))
+	}
+
+	data := make(url.Values)
+	data.Add("domain-name", r.DomainName)
+	data.Add("years", strconv.Itoa(r.Years))
+	data["ns"] = append(data["ns"], r.NameServers...)
+	data.Add("customer-id", r.CustomerID)
+	data.Add("reg-contact-id", r.RegContactID)
+	data.Add("admin-contact-id", r.AdminContactID)
+	data.Add("tech-contact-id", r.TechContactID)
+	data.Add("billing-contact-id", r.BillingContactID)
+	data.Add("invoice-option", string(r.InvoiceOption))
+	data.Add("purchase-privacy", strconv.FormatBool(r.PurchasePrivacy))
+	data.Add("protect-privacy", strconv.FormatBool(r.ProtectPrivacy))
+	data.Add("auto-renew", strconv.FormatBool(r.AutoRenew))
+	data.Add("discount-amount", strconv.FormatFloat(r.DiscountAmount, 'f', 2, 64))
+	data.Add("purchase-premium-dns", strconv.FormatBool(r.PurchasePremiumDNS))
+	if attributes != nil {
+		attributes.CopyTo(&data)
+	}
+
+	return data, nil
+}
+
+// URLValues validates the request and returns the parameters of domains/transfer. Internationalized
+// domain and name server names are sent as A-labels.
+func (r *TransferRequest) URLValues() (url.Values, error) {
+	asciiName, err := ToASCII(r.DomainName)
+	if err != nil {
+		return nil, err
+	}
+	nameServers, err := toASCIIAll(r.NameServers)
+	if err != nil {
+		return nil, err
+	}
+	req := *r
+	req.DomainName, req.NameServers = asciiName, nameServers
+	if err := validateRequest(&req); err != nil {
+		return nil, err
+	}
+	r = &req
+
+	data := make(url.Values)
+	data.Add("domain-name", r.DomainName)
+	if r.AuthCode != "" {
+		data.Add("auth-code", r.AuthCode)
+	}
+	data["ns"] = append(data["ns"], r.NameServers...)
+	data.Add("customer-id", r.CustomerID)
+	data.Add("reg-contact-id", r.RegContactID)
+	data.Add("admin-contact-id", r.AdminContactID)
+	data.Add("tech-contact-id", r.TechContactID)
+	data.Add("billing-contact-id", r.BillingContactID)
+	data.Add("invoice-option", string(r.InvoiceOption))
+	data.Add("purchase-privacy", strconv.FormatBool(r.PurchasePrivacy))
+	data.Add("protect-privacy", strconv.FormatBool(r.ProtectPrivacy))
+	data.Add("auto-renew", strconv.FormatBool(r.AutoRenew))
+	data.Add("purchase-premium-dns", strconv.FormatBool(r.PurchasePremiumDNS))
+	if r.Attributes != nil {
+		r.Attributes.CopyTo(&data)
+	}
+
+	return data, nil
+}
+
+// singleAttribute converts the attrName/attrValue pair of the positional methods.
+func singleAttribute(attrName, attrValue string) core.EntityAttributes {
+	if attrName == "" {
+		return nil
+	}
+	attributes := core.NewEntityAttributes()
+	attributes.Add(attrName, attrValue)
+	return attributes
+}
+
+// withAttribute returns a copy of attributes with key set, leaving the caller's attributes untouched.
+func withAttribute(attributes core.EntityAttributes, key, val string) core.EntityAttributes {
+	ret := core.NewEntityAttributes()
+	if attributes != nil {
+		values := attributes.URLValues()
+		for i := 1; values.Has("attr-name" + strconv.Itoa(i)); i++ {
+			ret.Add(values.Get("attr-name"+strconv.Itoa(i)), values.Get("attr-value"+strconv.Itoa(i)))
+		}
+	}
+	ret.Add(key, val)
+	return ret
+}
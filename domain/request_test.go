@@ -0,0 +1,147 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+// addRegistrant adds a customer with a contact to the fake and returns their IDs.
+func addRegistrant(srv *rctest.Server) (customerID, contactID string) {
+	customerID = srv.AddCustomer(rctest.Customer{Username: "jane@example.com", Name: "Jane Doe"})
+	contactID = srv.AddContact(rctest.Contact{CustomerID: customerID, Name: "Jane Doe", Email: "jane@example.com"})
+	return customerID, contactID
+}
+
+func registerRequest(customerID, contactID string) *domain.RegisterRequest {
+	return &domain.RegisterRequest{
+		DomainName:       "example.com",
+		Years:            2,
+		NameServers:      []string{"ns1.example.net", "ns2.example.net"},
+		CustomerID:       customerID,
+		RegContactID:     contactID,
+		AdminContactID:   contactID,
+		TechContactID:    contactID,
+		BillingContactID: contactID,
+		InvoiceOption:    domain.InvoiceNo,
+	}
+}
+
+func TestRegisterDomain(t *testing.T) {
+	srv, d := newFake(t)
+	customerID, contactID := addRegistrant(srv)
+
+	req := registerRequest(customerID, contactID)
+	req.Attributes = core.NewEntityAttributes()
+	req.Attributes.Add("id-number", "123")
+	req.Attributes.Add("id-type", "passport")
+	res, err := d.RegisterDomain(context.Background(), req)
+	require.NoError(t, err)
+
+	order, ok := srv.Order(res.EntityID)
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"id-number": "123", "id-type": "passport"}, order.Attributes)
+	require.Equal(t, []string{"ns1.example.net", "ns2.example.net"}, order.NameServers)
+}
+
+func TestRegisterDomainValidation(t *testing.T) {
+	srv, d := newFake(t)
+
+	tests := map[string]func(r *domain.RegisterRequest){
+		"zero years":               func(r *domain.RegisterRequest) { r.Years = 0 },
+		"eleven years":             func(r *domain.RegisterRequest) { r.Years = 11 },
+		"no name servers":          func(r *domain.RegisterRequest) { r.NameServers = nil },
+		"invalid name server":      func(r *domain.RegisterRequest) { r.NameServers = []string{"not a host"} },
+		"single label name server": func(r *domain.RegisterRequest) { r.NameServers = []string{"localhost"} },
+		"numeric tld":              func(r *domain.RegisterRequest) { r.DomainName = "example.123" },
+		"too many name servers": func(r *domain.RegisterRequest) {
+			r.NameServers = make([]string, 14)
+			for i := range r.NameServers {
+				r.NameServers[i] = "ns.example.net"
+			}
+		},
+		"non-numeric contact":    func(r *domain.RegisterRequest) { r.TechContactID = "abc" },
+		"missing customer":       func(r *domain.RegisterRequest) { r.CustomerID = "" },
+		"unknown invoice option": func(r *domain.RegisterRequest) { r.InvoiceOption = "Later" },
+		"negative discount":      func(r *domain.RegisterRequest) { r.DiscountAmount = -1 },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := registerRequest("1", "2")
+			mutate(req)
+			_, err := d.RegisterDomain(context.Background(), req)
+			require.ErrorIs(t, err, core.ErrValidation)
+		})
+	}
+	require.Empty(t, srv.Calls())
+
+	req := registerRequest("1", "2")
+	req.AdminContactID = "-1"
+	_, err := req.URLValues()
+	require.NoError(t, err)
+}
+
+func TestTransferDomain(t *testing.T) {
+	srv, d := newFake(t)
+	customerID, contactID := addRegistrant(srv)
+
+	res, err := d.TransferDomain(context.Background(), &domain.TransferRequest{
+		DomainName:       "example.org",
+		AuthCode:         "secret",
+		CustomerID:       customerID,
+		RegContactID:     contactID,
+		AdminContactID:   contactID,
+		TechContactID:    contactID,
+		BillingContactID: contactID,
+		InvoiceOption:    domain.InvoiceKeep,
+	})
+	require.NoError(t, err)
+
+	order, ok := srv.Order(res.EntityID)
+	require.True(t, ok)
+	require.Equal(t, "secret", order.AuthCode)
+
+	_, err = d.TransferDomain(context.Background(), &domain.TransferRequest{DomainName: "example.org"})
+	require.ErrorIs(t, err, core.ErrValidation)
+}
+
+func TestRegisterPositional(t *testing.T) {
+	srv, d := newFake(t)
+	customerID, contactID := addRegistrant(srv)
+
+	res, err := d.Register(context.Background(), "example.net", 1, []string{"ns1.example.net"},
+		customerID, contactID, contactID, contactID, contactID, "NoInvoice",
+		false, false, true, "cpr", "1234", 0, false)
+	require.NoError(t, err)
+
+	order, ok := srv.Order(res.EntityID)
+	require.True(t, ok)
+	require.True(t, order.AutoRenew)
+	require.Equal(t, map[string]string{"cpr": "1234"}, order.Attributes)
+}
+
+func TestRegisterPositionalIDNTLD(t *testing.T) {
+	srv, d := newFake(t)
+	customerID, contactID := addRegistrant(srv)
+
+	res, err := d.Register(context.Background(), "example.xn--p1ai", 1, []string{"ns1.example.xn--p1ai", "ns2.example.net"},
+		customerID, contactID, contactID, contactID, contactID, "NoInvoice",
+		false, false, false, "", "", 0, false)
+	require.NoError(t, err)
+	order, ok := srv.Order(res.EntityID)
+	require.True(t, ok)
+	require.Equal(t, "example.xn--p1ai", order.DomainName)
+	require.Equal(t, []string{"ns1.example.xn--p1ai", "ns2.example.net"}, order.NameServers)
+
+	res, err = d.Transfer(context.Background(), "example.xn--c1avg", "secret",
+		customerID, contactID, contactID, contactID, contactID, "NoInvoice",
+		false, false, false, []string{"ns1.example.xn--p1ai"}, "", "", false)
+	require.NoError(t, err)
+	order, ok = srv.Order(res.EntityID)
+	require.True(t, ok)
+	require.Equal(t, "example.xn--c1avg", order.DomainName)
+}
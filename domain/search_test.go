@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func newFake(t *testing.T) (*rctest.Server, domain.Domain) {
+	t.Helper()
+	srv := rctest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv, domain.New(core.New(srv.Config(), srv.Client()))
+}
+
+func addOrders(srv *rctest.Server, customerID string, n int) {
+	for i := range n {
+		srv.AddOrder(rctest.Order{
+			DomainName: fmt.Sprintf("example-%02d.com", i),
+			CustomerID: customerID,
+			EndTime:    time.Date(2030, 1, 1+i, 0, 0, 0, 0, time.UTC),
+		})
+	}
+}
+
+func TestSearchOrders(t *testing.T) {
+	srv, d := newFake(t)
+	addOrders(srv, "100", 25)
+	addOrders(srv, "200", 3)
+
+	res, err := d.SearchOrders(context.Background(), domain.OrderCriteria{
+		Criteria: core.Criteria{CustomerIDs: []string{"100"}},
+	}, 3, 10)
+	require.NoError(t, err)
+	require.Equal(t, 25, res.TotalMatched)
+	require.Equal(t, 5, res.OnPage)
+	require.Len(t, res.Orders, 5)
+
+	first := res.Orders[0]
+	require.Equal(t, "example-20.com", first.DomainName)
+	require.Equal(t, "Active", first.Status)
+	require.Equal(t, "100", first.CustomerID)
+	require.Equal(t, core.DomainKey("domcno"), first.ProductKey)
+	require.Equal(t, time.Date(2030, 1, 21, 0, 0, 0, 0, time.UTC), first.ExpiryTime.ToTime().UTC())
+	require.NotEmpty(t, first.OrderID)
+
+	call := srv.Calls()[0]
+	require.Equal(t, "10", call.Params.Get("no-of-records"))
+	require.Equal(t, "3", call.Params.Get("page-no"))
+
+	_, err = d.SearchOrders(context.Background(), domain.OrderCriteria{}, 1, 5)
+	require.Error(t, err)
+}
+
+func TestSearchOrdersExpiryRange(t *testing.T) {
+	srv, d := newFake(t)
+	addOrders(srv, "100", 10)
+
+	res, err := d.SearchOrders(context.Background(), domain.OrderCriteria{
+		TimeExpiryStart: time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC),
+		TimeExpiryEnd:   time.Date(2030, 1, 5, 0, 0, 0, 0, time.UTC),
+	}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, res.TotalMatched)
+}
+
+func TestSearchAllOrders(t *testing.T) {
+	srv, d := newFake(t)
+	addOrders(srv, "100", 25)
+	ctx := context.Background()
+
+	var names []string
+	for order, err := range d.SearchAllOrders(ctx, domain.OrderCriteria{}, 10) {
+		require.NoError(t, err)
+		names = append(names, order.DomainName)
+	}
+	require.Len(t, names, 25)
+	require.Equal(t, "example-24.com", names[24])
+	require.Len(t, srv.Calls(), 3)
+
+	for range d.SearchAllOrders(ctx, domain.OrderCriteria{}, 10) {
+		break
+	}
+	require.Len(t, srv.Calls(), 4)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	for _, err := range d.SearchAllOrders(canceled, domain.OrderCriteria{}, 10) {
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	require.Len(t, srv.Calls(), 4)
+}
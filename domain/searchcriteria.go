@@ -22,7 +22,7 @@ type OrderCriteria struct {
 	PrivacyStatus   PrivacyState        `validate:"omitempty" query:"privacy-enabled,omitempty"`
 	ShowChildOrders bool                `validate:"omitempty" query:"show-child-orders,omitempty"`
 	TimeExpiryStart time.Time           `validate:"omitempty" query:"expiry-date-start,omitempty"`
-	TimeExpiryEnd   time.Time           `validate:"omitempty" query:"expiry-date-start,omitempty"`
+	TimeExpiryEnd   time.Time           `validate:"omitempty" query:"expiry-date-end,omitempty"`
 }
 
 // URLValues godoc
@@ -108,5 +108,14 @@ func (c OrderCriteria) URLValues() (url.Values, error) {
 	}
 
 	wg.Wait()
+
+	embedded, err := c.Criteria.URLValues()
+	if err != nil {
+		return url.Values{}, err
+	}
+	for k, v := range embedded {
+		urlValues[k] = v
+	}
+
 	return urlValues, nil
 }
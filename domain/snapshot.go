@@ -0,0 +1,319 @@
+package domain
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SnapshotEntry is the audited state of one domain order.
+type SnapshotEntry struct {
+	OrderID             string          `json:"order_id"`
+	DomainName          string          `json:"domain_name"`
+	CustomerID          string          `json:"customer_id"`
+	Status              string          `json:"status"`
+	ExpiryTime          time.Time       `json:"expiry_time"`
+	RegistrantContactID string          `json:"registrant_contact_id"`
+	AdminContactID      string          `json:"admin_contact_id"`
+	TechContactID       string          `json:"tech_contact_id"`
+	BillingContactID    string          `json:"billing_contact_id"`
+	RegistrantContact   SnapshotContact `json:"registrant_contact"`
+	AdminContact        SnapshotContact `json:"admin_contact"`
+	TechContact         SnapshotContact `json:"tech_contact"`
+	NameServers         []string        `json:"name_servers"`
+	TransferLock        bool            `json:"transfer_lock"`
+	CustomerLock        bool            `json:"customer_lock"`
+	PrivacyProtected    bool            `json:"privacy_protected"`
+	AutoRenew           bool            `json:"auto_renew"`
+}
+
+// SnapshotContact is the identity of a contact, audited since it can change without its ID.
+type SnapshotContact struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Company string `json:"company"`
+}
+
+func snapshotContactOf(c Contact) SnapshotContact {
+	return SnapshotContact{Name: c.Name, Email: strings.ToLower(c.EmailAddr), Company: c.Company}
+}
+
+// snapshotFields are the CSV columns and the fields compared by DiffSnapshots, in order.
+var snapshotFields = []string{
+	"order_id", "domain_name", "customer_id", "status", "expiry_time",
+	"registrant_contact_id", "admin_contact_id", "tech_contact_id", "billing_contact_id",
+	"registrant_name", "registrant_email", "registrant_company", "admin_name", "admin_email", "admin_company",
+	"tech_name", "tech_email", "tech_company",
+	"name_servers", "transfer_lock", "customer_lock", "privacy_protected", "auto_renew",
+}
+
+func (e SnapshotEntry) values() []string {
+	return []string{
+		e.OrderID, e.DomainName, e.CustomerID, e.Status, e.ExpiryTime.UTC().Format(time.RFC3339),
+		e.RegistrantContactID, e.AdminContactID, e.TechContactID, e.BillingContactID,
+		e.RegistrantContact.Name, e.RegistrantContact.Email, e.RegistrantContact.Company,
+		e.AdminContact.Name, e.AdminContact.Email, e.AdminContact.Company,
+		e.TechContact.Name, e.TechContact.Email, e.TechContact.Company,
+		strings.Join(e.NameServers, " "), strconv.FormatBool(e.TransferLock), strconv.FormatBool(e.CustomerLock),
+		strconv.FormatBool(e.PrivacyProtected), strconv.FormatBool(e.AutoRenew),
+	}
+}
+
+func snapshotEntryOf(values []string) (SnapshotEntry, error) {
+	if len(values) != len(snapshotFields) {
+		return SnapshotEntry{}, fmt.Errorf("expected %d columns, got %d", len(snapshotFields), len(values))
+	}
+	expiry, err := time.Parse(time.RFC3339, values[4])
+	if err != nil {
+		return SnapshotEntry{}, err
+	}
+	var nameServers []string
+	if values[18] != "" {
+		nameServers = strings.Split(values[18], " ")
+	}
+	var bools [4]bool
+	for i := range bools {
+		if bools[i], err = strconv.ParseBool(values[19+i]); err != nil {
+			return SnapshotEntry{}, err
+		}
+	}
+	return SnapshotEntry{
+		OrderID:             values[0],
+		DomainName:          values[1],
+		CustomerID:          values[2],
+		Status:              values[3],
+		ExpiryTime:          expiry,
+		RegistrantContactID: values[5],
+		AdminContactID:      values[6],
+		TechContactID:       values[7],
+		BillingContactID:    values[8],
+		RegistrantContact:   SnapshotContact{Name: values[9], Email: values[10], Company: values[11]},
+		AdminContact:        SnapshotContact{Name: values[12], Email: values[13], Company: values[14]},
+		TechContact:         SnapshotContact{Name: values[15], Email: values[16], Company: values[17]},
+		NameServers:         nameServers,
+		TransferLock:        bools[0],
+		CustomerLock:        bools[1],
+		PrivacyProtected:    bools[2],
+		AutoRenew:           bools[3],
+	}, nil
+}
+
+// Snapshot is the state of a domain portfolio at a point in time, sorted by domain name.
+type Snapshot struct {
+	TakenAt time.Time       `json:"taken_at"`
+	Entries []SnapshotEntry `json:"entries"`
+}
+
+// SnapshotOptions selects the orders of a snapshot.
+type SnapshotOptions struct {
+	// Criteria filters the orders, all orders when empty.
+	Criteria OrderCriteria
+	// Concurrency is the number of orders fetched in parallel, DefaultBulkConcurrency when zero.
+	Concurrency int
+}
+
+// snapshotDetailOptions are the details options holding the audited fields.
+var snapshotDetailOptions = []DetailOption{
+	DetailOrderDetails, DetailNameServers, DetailContactIDs, DetailRegistrantContact, DetailAdminContact, DetailTechContact,
+}
+
+// TakeSnapshot fetches the details and locks of every order matching the criteria. It fails if any
+// order cannot be fetched, a partial snapshot would report the missing orders as removed.
+func TakeSnapshot(ctx context.Context, d Domain, opts SnapshotOptions) (*Snapshot, error) {
+	snapshot := &Snapshot{TakenAt: time.Now().UTC()}
+	var orderIDs []string
+	for order, err := range d.SearchAllOrders(ctx, opts.Criteria, 100) {
+		if err != nil {
+			return nil, err
+		}
+		orderIDs = append(orderIDs, order.OrderID)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	entries := make([]SnapshotEntry, len(orderIDs))
+	mu := sync.Mutex{}
+	var errs []error
+
+	forEachConcurrently(indexes(orderIDs), concurrency, func(i int) {
+		entry, err := snapshotEntry(ctx, d, orderIDs[i])
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, fmt.Errorf("order %s: %w", orderIDs[i], err))
+			return
+		}
+		entries[i] = entry
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(entries, func(a, b SnapshotEntry) int { return strings.Compare(a.DomainName, b.DomainName) })
+	snapshot.Entries = entries
+	return snapshot, nil
+}
+
+func snapshotEntry(ctx context.Context, d Domain, orderID string) (SnapshotEntry, error) {
+	detail, err := d.GetRegistrationOrderDetails(ctx, orderID, snapshotDetailOptions)
+	if err != nil {
+		return SnapshotEntry{}, err
+	}
+	locks, err := d.GetTheListOfLocksAppliedOnDomainName(ctx, orderID)
+	if err != nil {
+		return SnapshotEntry{}, err
+	}
+
+	nameServers := detail.NameServers()
+	for i, ns := range nameServers {
+		nameServers[i] = strings.ToLower(ns)
+	}
+	return SnapshotEntry{
+		OrderID:             orderID,
+		DomainName:          strings.ToLower(detail.DomainName),
+		CustomerID:          detail.CustomerID,
+		Status:              detail.CurrentStatus,
+		ExpiryTime:          detail.EndTime.ToTime().UTC(),
+		RegistrantContactID: detail.RegistrantContactID,
+		AdminContactID:      detail.AdminContactID,
+		TechContactID:       detail.TechContactID,
+		BillingContactID:    detail.BillingContactID,
+		RegistrantContact:   snapshotContactOf(detail.RegistrantContact),
+		AdminContact:        snapshotContactOf(detail.Admincontact),
+		TechContact:         snapshotContactOf(detail.TechContact),
+		NameServers:         nameServers,
+		TransferLock:        locks.TransferLock,
+		CustomerLock:        locks.CustomerLock,
+		PrivacyProtected:    detail.IsPrivacyProtected.ToBool(),
+		AutoRenew:           detail.Recurring.ToBool(),
+	}, nil
+}
+
+// WriteJSON writes the snapshot as indented JSON.
+func (s *Snapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// WriteCSV writes the entries as CSV with a header row. Name servers are separated by spaces. The
+// snapshot time is not part of the CSV.
+func (s *Snapshot) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(snapshotFields); err != nil {
+		return err
+	}
+	for _, e := range s.Entries {
+		if err := cw.Write(e.values()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadSnapshotJSON reads a snapshot written by WriteJSON.
+func ReadSnapshotJSON(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ReadSnapshotCSV reads a snapshot written by WriteCSV. Its TakenAt is zero.
+func ReadSnapshotCSV(r io.Reader) (*Snapshot, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || !slices.Equal(records[0], snapshotFields) {
+		return nil, errors.New("snapshot csv header does not match")
+	}
+
+	s := &Snapshot{}
+	for i, record := range records[1:] {
+		entry, err := snapshotEntryOf(record)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot csv line %d: %w", i+2, err)
+		}
+		s.Entries = append(s.Entries, entry)
+	}
+	return s, nil
+}
+
+// SnapshotChangeKind tells whether a domain was added, removed or changed between two snapshots.
+type SnapshotChangeKind string
+
+// Const for snapshot change kinds.
+const (
+	SnapshotAdded   SnapshotChangeKind = "added"
+	SnapshotRemoved SnapshotChangeKind = "removed"
+	SnapshotChanged SnapshotChangeKind = "changed"
+)
+
+// FieldChange is a field whose value differs between two snapshots, in its CSV form.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// SnapshotChange lists the changed fields of a domain. Fields is empty for added and removed
+// domains.
+type SnapshotChange struct {
+	DomainName string             `json:"domain_name"`
+	Kind       SnapshotChangeKind `json:"kind"`
+	Fields     []FieldChange      `json:"fields,omitempty"`
+}
+
+// DiffSnapshots compares two snapshots by domain name and returns the differences sorted by
+// domain name. The order of name servers is ignored.
+func DiffSnapshots(old, cur *Snapshot) []SnapshotChange {
+	index := func(s *Snapshot) map[string]SnapshotEntry {
+		ret := make(map[string]SnapshotEntry, len(s.Entries))
+		for _, e := range s.Entries {
+			e.NameServers = slices.Sorted(slices.Values(e.NameServers))
+			ret[strings.ToLower(e.DomainName)] = e
+		}
+		return ret
+	}
+	before, after := index(old), index(cur)
+
+	var changes []SnapshotChange
+	for name, a := range after {
+		b, ok := before[name]
+		if !ok {
+			changes = append(changes, SnapshotChange{DomainName: name, Kind: SnapshotAdded})
+			continue
+		}
+		change := SnapshotChange{DomainName: name, Kind: SnapshotChanged}
+		oldValues, newValues := b.values(), a.values()
+		for i, field := range snapshotFields {
+			if oldValues[i] != newValues[i] {
+				change.Fields = append(change.Fields, FieldChange{Field: field, Old: oldValues[i], New: newValues[i]})
+			}
+		}
+		if len(change.Fields) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changes = append(changes, SnapshotChange{DomainName: name, Kind: SnapshotRemoved})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b SnapshotChange) int { return strings.Compare(a.DomainName, b.DomainName) })
+	return changes
+}
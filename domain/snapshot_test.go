@@ -0,0 +1,127 @@
+package domain_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	srv, d := newFake(t)
+	ctx := context.Background()
+	expiry := time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := srv.AddOrder(rctest.Order{
+		DomainName:          "example.com",
+		CustomerID:          "1",
+		EndTime:             expiry,
+		NameServers:         []string{"ns1.provider.net", "ns2.provider.net"},
+		RegistrantContactID: "11",
+		AdminContactID:      "12",
+		TechContactID:       "12",
+		BillingContactID:    "12",
+		TheftProtection:     true,
+		AutoRenew:           true,
+	})
+	srv.AddOrder(rctest.Order{DomainName: "example.net", CustomerID: "1", EndTime: expiry, PrivacyProtected: true})
+
+	before, err := domain.TakeSnapshot(ctx, d, domain.SnapshotOptions{})
+	require.NoError(t, err)
+	require.Len(t, before.Entries, 2)
+	require.Equal(t, domain.SnapshotEntry{
+		OrderID:             first,
+		DomainName:          "example.com",
+		CustomerID:          "1",
+		Status:              "Active",
+		ExpiryTime:          expiry,
+		RegistrantContactID: "11",
+		AdminContactID:      "12",
+		TechContactID:       "12",
+		BillingContactID:    "12",
+		NameServers:         []string{"ns1.provider.net", "ns2.provider.net"},
+		TransferLock:        true,
+		AutoRenew:           true,
+	}, before.Entries[0])
+
+	var buf bytes.Buffer
+	require.NoError(t, before.WriteJSON(&buf))
+	decoded, err := domain.ReadSnapshotJSON(&buf)
+	require.NoError(t, err)
+	require.Empty(t, domain.DiffSnapshots(before, decoded))
+
+	buf.Reset()
+	require.NoError(t, before.WriteCSV(&buf))
+	decoded, err = domain.ReadSnapshotCSV(&buf)
+	require.NoError(t, err)
+	require.Equal(t, before.Entries, decoded.Entries)
+
+	srv.UpdateOrder(first, func(o *rctest.Order) {
+		o.NameServers = []string{"ns2.provider.net", "ns.attacker.example"}
+		o.AdminContactID = "99"
+		o.TheftProtection = false
+	})
+	srv.AddOrder(rctest.Order{DomainName: "example.org", CustomerID: "1"})
+	srv.UpdateOrder(decoded.Entries[1].OrderID, func(o *rctest.Order) { o.Status = "Deleted" })
+
+	after, err := domain.TakeSnapshot(ctx, d, domain.SnapshotOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []domain.SnapshotChange{
+		{
+			DomainName: "example.com",
+			Kind:       domain.SnapshotChanged,
+			Fields: []domain.FieldChange{
+				{Field: "admin_contact_id", Old: "12", New: "99"},
+				{Field: "name_servers", Old: "ns1.provider.net ns2.provider.net", New: "ns.attacker.example ns2.provider.net"},
+				{Field: "transfer_lock", Old: "true", New: "false"},
+			},
+		},
+		{DomainName: "example.net", Kind: domain.SnapshotRemoved},
+		{DomainName: "example.org", Kind: domain.SnapshotAdded},
+	}, domain.DiffSnapshots(before, after))
+}
+
+func TestSnapshotContactChange(t *testing.T) {
+	srv, d := newFake(t)
+	ctx := context.Background()
+	registrantID := srv.AddContact(rctest.Contact{Name: "Jane Doe", Email: "jane@example.com", Company: "Example Ltd"})
+	adminID := srv.AddContact(rctest.Contact{Name: "Ops", Email: "ops@example.com", Company: "Example Ltd"})
+	srv.AddOrder(rctest.Order{
+		DomainName:          "example.com",
+		RegistrantContactID: registrantID,
+		AdminContactID:      adminID,
+		TechContactID:       adminID,
+		BillingContactID:    adminID,
+	})
+
+	before, err := domain.TakeSnapshot(ctx, d, domain.SnapshotOptions{})
+	require.NoError(t, err)
+	require.Len(t, before.Entries, 1)
+	require.Equal(t, domain.SnapshotContact{Name: "Jane Doe", Email: "jane@example.com", Company: "Example Ltd"},
+		before.Entries[0].RegistrantContact)
+	require.Equal(t, "ops@example.com", before.Entries[0].TechContact.Email)
+
+	var buf bytes.Buffer
+	require.NoError(t, before.WriteCSV(&buf))
+	decoded, err := domain.ReadSnapshotCSV(&buf)
+	require.NoError(t, err)
+	require.Equal(t, before.Entries, decoded.Entries)
+
+	srv.UpdateContact(registrantID, func(c *rctest.Contact) { c.Email = "attacker@example.net" })
+
+	after, err := domain.TakeSnapshot(ctx, d, domain.SnapshotOptions{})
+	require.NoError(t, err)
+	require.Equal(t, before.Entries[0].RegistrantContactID, after.Entries[0].RegistrantContactID)
+	require.Equal(t, []domain.SnapshotChange{
+		{
+			DomainName: "example.com",
+			Kind:       domain.SnapshotChanged,
+			Fields: []domain.FieldChange{
+				{Field: "registrant_email", Old: "jane@example.com", New: "attacker@example.net"},
+			},
+		},
+	}, domain.DiffSnapshots(decoded, after))
+}
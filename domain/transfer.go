@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// TransferState is the progress of an inbound transfer.
+type TransferState string
+
+// Const for transfer states.
+const (
+	// TransferPendingApproval waits for the registrant to approve the transfer.
+	TransferPendingApproval TransferState = "pending-approval"
+	// TransferPendingRegistry waits for the registry and the losing registrar.
+	TransferPendingRegistry TransferState = "pending-registry"
+	TransferCompleted       TransferState = "completed"
+	TransferCancelled       TransferState = "cancelled"
+	TransferFailed          TransferState = "failed"
+	TransferUnknown         TransferState = "unknown"
+)
+
+// Done reports whether the transfer reached a final state.
+func (s TransferState) Done() bool {
+	return s == TransferCompleted || s == TransferCancelled || s == TransferFailed
+}
+
+// TransferStateOf derives the transfer state from the order details, which must include the
+// OrderDetails and DomainStatus options.
+func TransferStateOf(detail *OrderDetail) TransferState {
+	hasStatus := func(statuses ...string) bool {
+		for _, status := range detail.DomainStatus {
+			if slices.Contains(statuses, strings.ToLower(status)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch core.EntityStatus(detail.CurrentStatus) {
+	case core.StatusActive:
+		return TransferCompleted
+	case core.StatusInActive:
+		if hasStatus("pendingtransfer") {
+			return TransferPendingRegistry
+		}
+		return TransferPendingApproval
+	case core.StatusDeleted, core.StatusArchived:
+		if hasStatus("transferfailed", "transferrejected") {
+			return TransferFailed
+		}
+		return TransferCancelled
+	}
+	return TransferUnknown
+}
+
+// TransferEvent is a state change of a tracked transfer, or a failed poll when Err is set. Action
+// is set when the tracker acted on a stuck transfer.
+type TransferEvent struct {
+	OrderID    string
+	DomainName string
+	From       TransferState
+	To         TransferState
+	// Since is when the transfer entered To, the order creation for the first state.
+	Since  time.Time
+	Action TransferAction
+	Err    error
+}
+
+// TransferAction is an action taken by the tracker on a stuck transfer.
+type TransferAction string
+
+// Const for transfer actions.
+const (
+	TransferActionResend TransferAction = "resend-approval-mail"
+	TransferActionCancel TransferAction = "cancel"
+)
+
+const (
+	defaultTransferMinInterval = time.Minute
+	defaultTransferMaxInterval = time.Hour
+)
+
+// TransferTracker polls the details of transfer orders until they complete, backing off while
+// their state does not change or the polls fail. An order that is not found is no longer tracked.
+type TransferTracker struct {
+	domain Domain
+
+	// MinInterval is the delay between polls after a state change, one minute when zero. It doubles
+	// on every poll without change, up to MaxInterval, one hour when zero.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// ResendAfter resends the approval mail once when a transfer is pending approval for longer.
+	// Zero disables it.
+	ResendAfter time.Duration
+	// CancelAfter cancels a transfer still pending approval or at the registry after this long. The
+	// cancel is attempted once. Zero disables it.
+	CancelAfter time.Duration
+}
+
+func NewTransferTracker(d Domain) *TransferTracker {
+	return &TransferTracker{domain: d}
+}
+
+// Track polls the orders until their transfers are done or ctx is done, then closes the returned
+// channel. The first event of every order has an empty From.
+func (t *TransferTracker) Track(ctx context.Context, orderIDs ...string) <-chan TransferEvent {
+	events := make(chan TransferEvent)
+	wg := sync.WaitGroup{}
+	for _, orderID := range orderIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.track(ctx, orderID, events)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+	return events
+}
+
+// Wait tracks the order until its transfer is done and returns the final state.
+func (t *TransferTracker) Wait(ctx context.Context, orderID string) (TransferState, error) {
+	state := TransferUnknown
+	for event := range t.Track(ctx, orderID) {
+		if event.Err == nil {
+			state = event.To
+		}
+	}
+	if state.Done() {
+		return state, nil
+	}
+	return state, ctx.Err()
+}
+
+// Resend resends the approval mail of a transfer pending approval.
+func (t *TransferTracker) Resend(ctx context.Context, orderID string) error {
+	_, err := t.domain.ResendTransferApprovalMail(ctx, orderID)
+	return err
+}
+
+// Cancel cancels a pending transfer.
+func (t *TransferTracker) Cancel(ctx context.Context, orderID string) error {
+	_, err := t.domain.CancelTransfer(ctx, orderID)
+	return err
+}
+
+func (t *TransferTracker) track(ctx context.Context, orderID string, events chan<- TransferEvent) {
+	minInterval, maxInterval := t.MinInterval, t.MaxInterval
+	if minInterval <= 0 {
+		minInterval = defaultTransferMinInterval
+	}
+	if maxInterval <= 0 {
+		maxInterval = defaultTransferMaxInterval
+	}
+
+	send := func(event TransferEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var (
+		current   TransferEvent
+		resent    bool
+		cancelled bool
+		interval  = minInterval
+	)
+	current.OrderID = orderID
+	for {
+		detail, err := t.domain.GetRegistrationOrderDetails(ctx, orderID, []DetailOption{DetailOrderDetails, DetailDomainStatus})
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			if !send(TransferEvent{OrderID: orderID, DomainName: current.DomainName, From: current.To, To: current.To, Err: err}) ||
+				errors.Is(err, core.ErrNotFound) {
+				return
+			}
+			interval = min(interval*2, maxInterval)
+		default:
+			state := TransferStateOf(detail)
+			if state != current.To {
+				since := time.Now()
+				if current.To == "" {
+					since = detail.CreationTime.ToTime()
+				}
+				current = TransferEvent{OrderID: orderID, DomainName: detail.DomainName, From: current.To, To: state, Since: since}
+				interval = minInterval
+				if !send(current) || state.Done() {
+					return
+				}
+				break
+			}
+
+			action := TransferAction("")
+			switch {
+			case t.CancelAfter > 0 && !cancelled && (state == TransferPendingApproval || state == TransferPendingRegistry) &&
+				time.Since(current.Since) > t.CancelAfter:
+				action, err = TransferActionCancel, t.Cancel(ctx, orderID)
+				cancelled = true
+			case t.ResendAfter > 0 && !resent && state == TransferPendingApproval && time.Since(current.Since) > t.ResendAfter:
+				action, err = TransferActionResend, t.Resend(ctx, orderID)
+				resent = true
+			}
+			if action != "" {
+				event := current
+				event.From, event.Action, event.Err = state, action, err
+				if !send(event) {
+					return
+				}
+				if action == TransferActionCancel && err == nil {
+					interval = minInterval
+					break
+				}
+			}
+			interval = min(interval*2, maxInterval)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package domain_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func newTracker(d domain.Domain) *domain.TransferTracker {
+	tracker := domain.NewTransferTracker(d)
+	tracker.MinInterval = time.Millisecond
+	tracker.MaxInterval = 5 * time.Millisecond
+	return tracker
+}
+
+func TestTransferTracker(t *testing.T) {
+	srv, d := newFake(t)
+	customerID, contactID := addRegistrant(srv)
+	res, err := d.TransferDomain(context.Background(), &domain.TransferRequest{
+		DomainName:       "example.org",
+		AuthCode:         "secret",
+		CustomerID:       customerID,
+		RegContactID:     contactID,
+		AdminContactID:   contactID,
+		TechContactID:    contactID,
+		BillingContactID: contactID,
+		InvoiceOption:    domain.InvoiceNo,
+	})
+	require.NoError(t, err)
+	orderID := res.EntityID
+	failedID := srv.AddOrder(rctest.Order{DomainName: "failed.org", Status: "InActive"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := newTracker(d).Track(ctx, orderID, failedID)
+
+	next := func(want string) domain.TransferEvent {
+		t.Helper()
+		for event := range events {
+			require.NoError(t, event.Err)
+			if event.OrderID == want {
+				return event
+			}
+		}
+		t.Fatal("events closed")
+		return domain.TransferEvent{}
+	}
+
+	event := next(orderID)
+	require.Equal(t, domain.TransferState(""), event.From)
+	require.Equal(t, domain.TransferPendingApproval, event.To)
+	require.Equal(t, "example.org", event.DomainName)
+
+	srv.UpdateOrder(orderID, func(o *rctest.Order) { o.DomainStatus = []string{"pendingTransfer"} })
+	event = next(orderID)
+	require.Equal(t, domain.TransferPendingApproval, event.From)
+	require.Equal(t, domain.TransferPendingRegistry, event.To)
+
+	srv.UpdateOrder(failedID, func(o *rctest.Order) {
+		o.Status = "Deleted"
+		o.DomainStatus = []string{"transferfailed"}
+	})
+	srv.UpdateOrder(orderID, func(o *rctest.Order) {
+		o.Status = "Active"
+		o.DomainStatus = nil
+	})
+
+	final := map[string]domain.TransferState{}
+	for event := range events {
+		require.NoError(t, event.Err)
+		final[event.OrderID] = event.To
+	}
+	require.Equal(t, domain.TransferCompleted, final[orderID])
+	require.Equal(t, domain.TransferFailed, final[failedID])
+}
+
+func TestTransferTrackerStuck(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{
+		DomainName:   "stuck.org",
+		Status:       "InActive",
+		CreationTime: time.Now().Add(-72 * time.Hour),
+	})
+
+	tracker := newTracker(d)
+	tracker.ResendAfter = 24 * time.Hour
+	tracker.CancelAfter = 48 * time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var actions []domain.TransferAction
+	var last domain.TransferEvent
+	for event := range tracker.Track(ctx, orderID) {
+		require.NoError(t, event.Err)
+		if event.Action != "" {
+			actions = append(actions, event.Action)
+		}
+		last = event
+	}
+	require.Equal(t, []domain.TransferAction{domain.TransferActionCancel}, actions)
+	require.Equal(t, domain.TransferCancelled, last.To)
+
+	orderID = srv.AddOrder(rctest.Order{
+		DomainName:   "slow.org",
+		Status:       "InActive",
+		CreationTime: time.Now().Add(-36 * time.Hour),
+	})
+	var resends int
+	srv.Handle("domains/resend-rfa", func(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+		resends++
+		srv.UpdateOrder(orderID, func(o *rctest.Order) { o.Status = "Active" })
+		rctest.WriteText(w, true)
+	})
+	state, err := tracker.Wait(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, domain.TransferCompleted, state)
+	require.Equal(t, 1, resends)
+}
+
+func TestTransferTrackerCancelOnce(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{
+		DomainName:   "stuck.org",
+		Status:       "InActive",
+		CreationTime: time.Now().Add(-72 * time.Hour),
+	})
+	var cancels atomic.Int32
+	srv.Handle("domains/cancel-transfer", func(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+		cancels.Add(1)
+		rctest.WriteError(w, http.StatusInternalServerError, "Transfer cannot be cancelled")
+	})
+
+	tracker := newTracker(d)
+	tracker.CancelAfter = 48 * time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var failed []domain.TransferEvent
+	for event := range tracker.Track(ctx, orderID) {
+		if event.Action == domain.TransferActionCancel {
+			failed = append(failed, event)
+		}
+	}
+	require.Len(t, failed, 1)
+	require.Error(t, failed[0].Err)
+	require.EqualValues(t, 1, cancels.Load())
+
+	// Orders that are not pending approval or at the registry are never cancelled, whatever their age.
+	orderID = srv.AddOrder(rctest.Order{
+		DomainName:   "old.org",
+		Status:       "Suspended",
+		CreationTime: time.Now().Add(-72 * time.Hour),
+	})
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	for event := range tracker.Track(ctx, orderID) {
+		require.Empty(t, event.Action)
+	}
+	require.EqualValues(t, 1, cancels.Load())
+}
+
+func TestTransferTrackerPollErrors(t *testing.T) {
+	srv, d := newFake(t)
+
+	// An unknown order is reported once and no longer polled.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var events []domain.TransferEvent
+	for event := range newTracker(d).Track(ctx, "404") {
+		events = append(events, event)
+	}
+	require.Len(t, events, 1)
+	require.ErrorIs(t, events[0].Err, core.ErrNotFound)
+	require.NoError(t, ctx.Err())
+
+	orderID := srv.AddOrder(rctest.Order{DomainName: "pending.org", Status: "InActive"})
+	var polls atomic.Int32
+	srv.Handle("domains/details", func(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+		polls.Add(1)
+		rctest.WriteError(w, http.StatusInternalServerError, "Internal error")
+	})
+
+	tracker := newTracker(d)
+	tracker.MinInterval = 10 * time.Millisecond
+	tracker.MaxInterval = time.Hour
+
+	// Polls at 0, 20, 60 and 140ms back off, fixed 10ms intervals would make 20 of them.
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var failures int
+	for event := range tracker.Track(ctx, orderID) {
+		require.Error(t, event.Err)
+		failures++
+	}
+	require.EqualValues(t, failures, polls.Load())
+	require.LessOrEqual(t, failures, 6)
+}
+
+func TestTransferTrackerCanceled(t *testing.T) {
+	srv, d := newFake(t)
+	orderID := srv.AddOrder(rctest.Order{DomainName: "pending.org", Status: "InActive"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	state, err := newTracker(d).Wait(ctx, orderID)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, domain.TransferPendingApproval, state)
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "github.com/mrehanabbasi/go-logicboxes/core"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
 
 type SortBy string
 
@@ -8,6 +13,8 @@ type (
 	PrivacyState       string
 	RegistrationStatus string
 	SortOrder          map[SortBy]bool
+	InvoiceOption      string
+	TELWhoisType       string
 )
 
 type SuggestNames map[string]struct {
@@ -35,88 +42,112 @@ type RegisterResponse struct {
 }
 
 type Contact struct {
-	Company       string   `json:"company"`
-	Address1      string   `json:"address1"`
-	Telno         string   `json:"telno"`
-	TelnoCC       string   `json:"telnocc"`
-	ContactID     string   `json:"contactid"`
-	Type          string   `json:"type"`
-	ContactType   []string `json:"contacttype"`
-	CustomerID    string   `json:"customerid"`
-	Country       string   `json:"country"`
-	ParentKey     string   `json:"parentkey"`
-	ContactStatus string   `json:"contactstatus"`
-	State         string   `json:"state"`
-	EmailAddr     string   `json:"emailaddr"`
-	City          string   `json:"city"`
-	Name          string   `json:"name"`
-	ZIP           string   `json:"zip"`
+	Company       string           `json:"company"`
+	Address1      string           `json:"address1"`
+	Address2      string           `json:"address2"`
+	Address3      string           `json:"address3"`
+	FaxNo         string           `json:"faxno"`
+	FaxNoCC       string           `json:"faxnocc"`
+	Telno         string           `json:"telno"`
+	TelnoCC       string           `json:"telnocc"`
+	ContactID     string           `json:"contactid"`
+	Type          string           `json:"type"`
+	ContactType   core.JSONStrings `json:"contacttype"`
+	CustomerID    string           `json:"customerid"`
+	Country       string           `json:"country"`
+	ParentKey     string           `json:"parentkey"`
+	ContactStatus string           `json:"contactstatus"`
+	State         string           `json:"state"`
+	EmailAddr     string           `json:"emailaddr"`
+	City          string           `json:"city"`
+	Name          string           `json:"name"`
+	ZIP           string           `json:"zip"`
+}
+
+// OrderSummary is a domain order as listed by SearchOrders.
+type OrderSummary struct {
+	OrderID           string         `json:"orders.orderid"`
+	DomainName        string         `json:"entity.description"`
+	Status            string         `json:"entity.currentstatus"`
+	CustomerID        string         `json:"entity.customerid"`
+	ProductKey        core.DomainKey `json:"entitytype.entitytypekey"`
+	CreationTime      core.JSONTime  `json:"orders.creationtime"`
+	ExpiryTime        core.JSONTime  `json:"orders.endtime"`
+	AutoRenew         core.JSONBool  `json:"orders.autorenew"`
+	PrivacyProtection core.JSONBool  `json:"orders.privacyprotection"`
+}
+
+type OrderSearchResult struct {
+	RequestedLimit  uint16
+	RequestedOffset uint16
+	TotalMatched    int
+	OnPage          int
+	Orders          []OrderSummary
 }
 
 type OrderDetail struct {
-	Classkey          string          `json:"classkey"`
-	AllowDeletion     core.JSONBool   `json:"allowdeletion"`
-	OrderID           string          `json:"orderid"`
-	NoOfNameServers   core.JSONUint16 `json:"noOfNameServers"`
-	ParentKey         string          `json:"parentkey"`
-	ProductCategory   string          `json:"productcategory"`
-	CurrentStatus     string          `json:"currentstatus"`
-	DomainName        string          `json:"domainname"`
-	Description       string          `json:"description"`
-	MultilingualFlag  string          `json:"multilingualflag"`
-	TNCRequired       core.JSONBool   `json:"tnc_required"`
-	PremiumDNSEnabled core.JSONBool   `json:"premiumdnsenabled"`
-	GDPR              struct {
-		Enabled  core.JSONBool `json:"enabled"`
-		Eligible core.JSONBool `json:"eligible"`
-	} `json:"gdpr"`
-	CustomerID                 string          `json:"customerid"`
-	Addons                     []string        `json:"addons"`
-	BulkWhoIsOptOut            string          `json:"bulkwhoisoptout"`
-	TechContactID              string          `json:"techcontactid"`
-	IsImmediateReseller        core.JSONBool   `json:"isImmediateReseller"`
-	CreationTime               core.JSONTime   `json:"creationtime"`
-	DNSSec                     []string        `json:"dnssec"`
-	JumpConditions             []string        `json:"jumpConditions"`
-	RaaVerificationStartTime   core.JSONTime   `json:"raaVerificationStartTime"`
-	CNS                        struct{}        `json:"cns"`
-	Paused                     core.JSONBool   `json:"paused"`
-	Admincontact               Contact         `json:"admincontact"`
-	BillingContactID           string          `json:"billingcontactid"`
-	PrivacyProtectedAllowed    core.JSONBool   `json:"privacyprotectedallowed"`
-	DomSecret                  string          `json:"domsecret"`
-	PremiumDNSAllowed          core.JSONBool   `json:"premiumdnsallowed"`
-	ServiceProviderID          string          `json:"serviceproviderid"`
-	Classname                  string          `json:"classname"`
-	ResellerCost               core.JSONUint16 `json:"resellercost"`
-	OrderStatus                []string        `json:"orderstatus"`
-	EaqID                      string          `json:"eaqid"`
-	EndTime                    core.JSONTime   `json:"endtime"`
-	BillingContact             Contact         `json:"billingcontact"`
-	AutoRenewTermType          string          `json:"autoRenewTermType"`
-	RaaVerificationStatus      string          `json:"raaVerificationStatus"`
-	EntityID                   string          `json:"entityid"`
-	Recurring                  core.JSONBool   `json:"recurring"`
-	ProductKey                 string          `json:"productkey"`
-	NS1                        string          `json:"ns1"`
-	NS2                        string          `json:"ns2"`
-	NS3                        string          `json:"ns3"`
-	NS4                        string          `json:"ns4"`
-	NS5                        string          `json:"ns5"`
-	NS6                        string          `json:"ns6"`
-	ActionCompleted            core.JSONUint16 `json:"actioncompleted"`
-	RegistrantContact          Contact         `json:"registrantcontact"`
-	EntityTypeID               string          `json:"entitytypeid"`
-	AutoRenewAttemptDuration   core.JSONUint16 `json:"autoRenewAttemptDuration"`
-	CustomerCost               core.JSONFloat  `json:"customercost"`
-	DomainStatus               []string        `json:"domainstatus"`
-	OrderSuspendedByParent     core.JSONBool   `json:"orderSuspendedByParent"`
-	MoneyBackPeriod            core.JSONUint16 `json:"moneybackperiod"`
-	TechContact                Contact         `json:"techcontact"`
-	RegistrantContactID        string          `json:"registrantcontactid"`
-	AdminContactID             string          `json:"admincontactid"`
-	IsOrderSuspendedUponExpiry core.JSONBool   `json:"isOrderSuspendedUponExpiry"`
-	IsPrivacyProtected         core.JSONBool   `json:"isprivacyprotected"`
+	Classkey                   string                `json:"classkey"`
+	AllowDeletion              core.JSONBool         `json:"allowdeletion"`
+	OrderID                    string                `json:"orderid"`
+	NoOfNameServers            core.JSONUint16       `json:"noOfNameServers"`
+	ParentKey                  string                `json:"parentkey"`
+	ProductCategory            string                `json:"productcategory"`
+	CurrentStatus              string                `json:"currentstatus"`
+	DomainName                 string                `json:"domainname"`
+	Description                string                `json:"description"`
+	MultilingualFlag           string                `json:"multilingualflag"`
+	TNCRequired                core.JSONBool         `json:"tnc_required"`
+	PremiumDNSEnabled          core.JSONBool         `json:"premiumdnsenabled"`
+	GDPR                       GDPRStatus            `json:"gdpr"`
+	CustomerID                 string                `json:"customerid"`
+	Addons                     core.JSONStrings      `json:"addons"`
+	BulkWhoIsOptOut            string                `json:"bulkwhoisoptout"`
+	TechContactID              string                `json:"techcontactid"`
+	IsImmediateReseller        core.JSONBool         `json:"isImmediateReseller"`
+	CreationTime               core.JSONTime         `json:"creationtime"`
+	DNSSec                     []DSRecord            `json:"dnssec"`
+	JumpConditions             core.JSONStrings      `json:"jumpConditions"`
+	RaaVerificationStartTime   core.JSONTime         `json:"raaVerificationStartTime"`
+	CNS                        ChildNameServers      `json:"cns"`
+	Paused                     core.JSONBool         `json:"paused"`
+	Admincontact               Contact               `json:"admincontact"`
+	BillingContactID           string                `json:"billingcontactid"`
+	PrivacyProtectedAllowed    core.JSONBool         `json:"privacyprotectedallowed"`
+	DomSecret                  string                `json:"domsecret"`
+	PremiumDNSAllowed          core.JSONBool         `json:"premiumdnsallowed"`
+	ServiceProviderID          string                `json:"serviceproviderid"`
+	Classname                  string                `json:"classname"`
+	ResellerCost               core.JSONFloat        `json:"resellercost"`
+	OrderStatus                core.JSONStrings      `json:"orderstatus"`
+	EaqID                      string                `json:"eaqid"`
+	EndTime                    core.JSONTime         `json:"endtime"`
+	BillingContact             Contact               `json:"billingcontact"`
+	AutoRenewTermType          string                `json:"autoRenewTermType"`
+	RaaVerificationStatus      RAAVerificationStatus `json:"raaVerificationStatus"`
+	EntityID                   string                `json:"entityid"`
+	Recurring                  core.JSONBool         `json:"recurring"`
+	ProductKey                 string                `json:"productkey"`
+	NS1                        string                `json:"ns1"`
+	NS2                        string                `json:"ns2"`
+	NS3                        string                `json:"ns3"`
+	NS4                        string                `json:"ns4"`
+	NS5                        string                `json:"ns5"`
+	NS6                        string                `json:"ns6"`
+	ActionCompleted            core.JSONUint16       `json:"actioncompleted"`
+	RegistrantContact          Contact               `json:"registrantcontact"`
+	EntityTypeID               string                `json:"entitytypeid"`
+	AutoRenewAttemptDuration   core.JSONUint16       `json:"autoRenewAttemptDuration"`
+	CustomerCost               core.JSONFloat        `json:"customercost"`
+	DomainStatus               core.JSONStrings      `json:"domainstatus"`
+	OrderSuspendedByParent     core.JSONBool         `json:"orderSuspendedByParent"`
+	MoneyBackPeriod            core.JSONUint16       `json:"moneybackperiod"`
+	TechContact                Contact               `json:"techcontact"`
+	RegistrantContactID        string                `json:"registrantcontactid"`
+	AdminContactID             string                `json:"admincontactid"`
+	IsOrderSuspendedUponExpiry core.JSONBool         `json:"isOrderSuspendedUponExpiry"`
+	IsPrivacyProtected         core.JSONBool         `json:"isprivacyprotected"`
+
+	nameServers []string
 }
 
 type NameServersResponse struct {
@@ -172,6 +203,35 @@ type TheftProtectionLockResponse struct {
 	ActionStatusDesc string `json:"actionstatusdesc"`
 }
 
+type DNSSECResponse struct {
+	ActionTypeDesc   string `json:"actiontypedesc"`
+	EntityID         string `json:"entityid"`
+	ActionStatus     string `json:"actionstatus"`
+	Status           string `json:"status"`
+	EaqID            string `json:"eaqid"`
+	Description      string `json:"description"`
+	ActionType       string `json:"actiontype"`
+	ActionStatusDesc string `json:"actionstatusdesc"`
+}
+
+// ActionResponse is the action queued by an order operation. The invoice fields are only set for
+// billed actions.
+type ActionResponse struct {
+	ActionTypeDesc          string         `json:"actiontypedesc"`
+	UnutilisedSellingAmount core.JSONFloat `json:"unutilisedsellingamount"`
+	SellingAmount           core.JSONFloat `json:"sellingamount"`
+	EntityID                string         `json:"entityid"`
+	ActionStatus            string         `json:"actionstatus"`
+	Status                  string         `json:"status"`
+	EaqID                   string         `json:"eaqid"`
+	CustomerID              string         `json:"customerid"`
+	Description             string         `json:"description"`
+	ActionType              string         `json:"actiontype"`
+	InvoiceID               string         `json:"invoiceid"`
+	SellingCurrencySymbol   string         `json:"sellingcurrencysymbol"`
+	ActionStatusDesc        string         `json:"actionstatusdesc"`
+}
+
 type GetTheListOfLocksAppliedOnDomainNameResponse struct {
 	TransferLock bool `json:"transferlock"`
 	CustomerLock bool `json:"customerlock"`
@@ -206,4 +266,44 @@ const (
 	DomRegUnregistered  RegistrationStatus = "available"
 	DomRegThroughUs     RegistrationStatus = "regthroughus"
 	DomRegThroughOthers RegistrationStatus = "regthroughothers"
+
+	// InvoiceNo does not raise an invoice, the order is executed and the customer's balance may go negative.
+	InvoiceNo InvoiceOption = "NoInvoice"
+	// InvoicePay raises an invoice and executes the order only if it can be paid from the customer's balance.
+	InvoicePay InvoiceOption = "PayInvoice"
+	// InvoiceKeep raises an invoice and executes the order, leaving the invoice pending if it can't be paid.
+	InvoiceKeep InvoiceOption = "KeepInvoice"
+	// InvoiceOnlyAdd raises an invoice and executes the order only once the invoice is paid.
+	InvoiceOnlyAdd InvoiceOption = "OnlyAdd"
+
+	// TELWhoisNatural is the .tel whois type of natural persons, who may hide their whois.
+	TELWhoisNatural TELWhoisType = "Natural"
+	// TELWhoisLegal is the .tel whois type of legal persons, whose whois is always published.
+	TELWhoisLegal TELWhoisType = "Legal"
 )
+
+// Validate checks that the option is one of the InvoiceOption constants.
+func (o InvoiceOption) Validate() error {
+	switch o {
+	case InvoiceNo, InvoicePay, InvoiceKeep, InvoiceOnlyAdd:
+		return nil
+	}
+	return fmt.Errorf("%w: invalid invoice option %q", core.ErrValidation, o)
+}
+
+// decodeActionResponse decodes an action, or the bare true some operations answer with.
+func decodeActionResponse(b []byte) (*ActionResponse, error) {
+	var ok core.JSONBool
+	if err := json.Unmarshal(b, &ok); err == nil {
+		if !ok.ToBool() {
+			return nil, fmt.Errorf("unexpected response: %s", b)
+		}
+		return &ActionResponse{Status: "Success"}, nil
+	}
+
+	var result ActionResponse
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -4,12 +4,10 @@ package domainforward
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
 
 	"github.com/mrehanabbasi/go-logicboxes/core"
 )
@@ -72,11 +70,7 @@ func (d *domainForward) ActivatingDomainForwardingService(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -108,11 +102,7 @@ func (d *domainForward) GettingDetailsDomainForwardingService(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result DetailsDomainForward
@@ -152,11 +142,7 @@ func (d *domainForward) ManagingDomainForwardingService(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result StdResponse
@@ -183,11 +169,7 @@ func (d *domainForward) GettingDNSRecords(ctx context.Context, domainName string
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result []*DNSRecord
@@ -214,11 +196,7 @@ func (d *domainForward) RemoveDomainForwardingForDomain(ctx context.Context, dom
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return false, err
-		}
-		return false, errors.New(strings.ToLower(errResponse.Message))
+		return false, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result bool
@@ -246,11 +224,7 @@ func (d *domainForward) DisableDomainForwardingForSubDomain(ctx context.Context,
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return false, err
-		}
-		return false, errors.New(strings.ToLower(errResponse.Message))
+		return false, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result bool
@@ -3,12 +3,10 @@ package general
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/mrehanabbasi/go-logicboxes/core"
@@ -197,12 +195,7 @@ func fetchCurrencyDB(ctx context.Context, c core.Core) (currencyDB, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	ret := make(map[string]map[string]string)
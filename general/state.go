@@ -3,11 +3,9 @@ package general
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
 	"sync"
 
 	"github.com/mrehanabbasi/go-logicboxes/core"
@@ -37,12 +35,7 @@ func fetchStateList(ctx context.Context, c core.Core, cc CountryISO) (States, er
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		err := json.Unmarshal(bytesResp, &errResponse)
-		if err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	keyPairs := map[string]string{}
@@ -5,6 +5,7 @@ go 1.23
 require (
 	github.com/go-playground/validator/v10 v10.24.0
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/net v0.34.0
 )
 
 require (
@@ -15,7 +16,6 @@ require (
 	github.com/leodido/go-urn v1.4.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/crypto v0.32.0 // indirect
-	golang.org/x/net v0.34.0 // indirect
 	golang.org/x/sys v0.29.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
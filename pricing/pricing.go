@@ -4,11 +4,9 @@ package pricing
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
 
 	"github.com/mrehanabbasi/go-logicboxes/core"
 )
@@ -44,11 +42,7 @@ func (p *pricing) GettingCustomerPricing(ctx context.Context, customerID string)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result CustomerPrice
@@ -75,11 +69,7 @@ func (p *pricing) GettingResellerPricing(ctx context.Context, resellerID string)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result ResellerPrice
@@ -106,11 +96,7 @@ func (p *pricing) GettingResellerCostPricing(ctx context.Context, resellerID str
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result ResellerCostPrice
@@ -136,11 +122,7 @@ func (p *pricing) GettingPromoPrices(ctx context.Context) (PromoPrice, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		errResponse := core.JSONStatusResponse{}
-		if err := json.Unmarshal(bytesResp, &errResponse); err != nil {
-			return nil, err
-		}
-		return nil, errors.New(strings.ToLower(errResponse.Message))
+		return nil, core.NewAPIError(resp, bytesResp)
 	}
 
 	var result PromoPrice
@@ -0,0 +1,233 @@
+package rctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// ErrNoInteraction is returned by a Replayer when the cassette has no unused interaction for a request.
+var ErrNoInteraction = errors.New("rctest: no recorded interaction")
+
+// Cassette is a list of recorded API interactions, stored as JSON in a fixture file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies an API call. Credentials are scrubbed and other sensitive parameters redacted.
+type RecordedRequest struct {
+	Method   string     `json:"method"`
+	Endpoint string     `json:"endpoint"`
+	Params   url.Values `json:"params"`
+}
+
+// RecordedResponse is the response returned for a RecordedRequest.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+func (r RecordedRequest) key() string {
+	return r.Method + " " + r.Endpoint + "?" + r.Params.Encode()
+}
+
+// LoadCassette reads a cassette from a fixture file.
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := new(Cassette)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("rctest: decoding cassette %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to a fixture file.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// recordRequest normalises req into a RecordedRequest. The request body, if any, is restored.
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	params := url.Values{}
+	for k, v := range req.URL.Query() {
+		params[k] = v
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return RecordedRequest{}, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return RecordedRequest{}, err
+		}
+		for k, v := range form {
+			params[k] = append(params[k], v...)
+		}
+	}
+	params.Del("auth-userid")
+	params.Del("api-key")
+
+	return RecordedRequest{
+		Method:   req.Method,
+		Endpoint: endpointOf(req.URL.Path),
+		Params:   core.RedactValues(params),
+	}, nil
+}
+
+// endpointOf returns the namespace/apiName part of an API path, e.g. "domains/v5/suggest-names".
+func endpointOf(path string) string {
+	if _, after, ok := strings.Cut(path, "/api/"); ok {
+		path = after
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(path, "/"), ".json")
+}
+
+// Recorder is an http.RoundTripper that forwards requests to the next transport and records the interactions.
+//
+//	rec := rctest.NewRecorder(nil)
+//	c := core.New(cfg, &http.Client{Transport: rec})
+//	...
+//	err := rec.Cassette().Save("testdata/dns.json")
+type Recorder struct {
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder forwarding to next, or http.DefaultTransport if next is nil.
+func NewRecorder(next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	header.Del("Date")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       string(body),
+		},
+	})
+	return resp, nil
+}
+
+// Cassette returns a copy of the interactions recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Replayer is an http.RoundTripper that serves responses from a cassette without touching the network.
+// A request matches an interaction with the same method, endpoint and parameters; each interaction is
+// replayed once, in recording order, so repeated calls can return different responses.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer serving the interactions of c.
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{
+		interactions: c.Interactions,
+		used:         make([]bool, len(c.Interactions)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	key := recorded.key()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.interactions {
+		if r.used[i] || in.Request.key() != key {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s", ErrNoInteraction, key)
+}
+
+// Unused returns the interactions that have not been replayed yet.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ret []Interaction
+	for i, in := range r.interactions {
+		if !r.used[i] {
+			ret = append(ret, in)
+		}
+	}
+	return ret
+}
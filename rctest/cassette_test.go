@@ -0,0 +1,85 @@
+package rctest_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrehanabbasi/go-logicboxes/contact"
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/dns"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	srv := rctest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	customerID := srv.AddCustomer(rctest.Customer{Username: "jane@example.com", Name: "Jane Doe"})
+	srv.AddContact(rctest.Contact{CustomerID: customerID, Name: "Jane Doe", Email: "jane@example.com"})
+
+	rec := rctest.NewRecorder(srv.Client().Transport)
+	c := core.New(srv.Config(), &http.Client{Transport: rec})
+	_, err := dns.New(c).AddingIPv4AddressRecord(ctx, "example.com", "192.0.2.1", "www", 3600)
+	require.NoError(t, err)
+	want, err := dns.New(c).SearchingDNSRecords(ctx, "example.com", dns.RecordA, 10, 1, "", "")
+	require.NoError(t, err)
+	wantContacts, err := contact.New(c).Search(ctx, contact.Criteria{CustomerID: customerID}, 1, 10)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, rec.Cassette().Save(path))
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), rctest.APIKey)
+	require.NotContains(t, string(raw), rctest.ResellerID)
+
+	srv.Close()
+	cassette, err := rctest.LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 3)
+
+	replayer := rctest.NewReplayer(cassette)
+	c = core.New(srv.Config(), &http.Client{Transport: replayer})
+	_, err = dns.New(c).AddingIPv4AddressRecord(ctx, "example.com", "192.0.2.1", "www", 3600)
+	require.NoError(t, err)
+	got, err := dns.New(c).SearchingDNSRecords(ctx, "example.com", dns.RecordA, 10, 1, "", "")
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	gotContacts, err := contact.New(c).Search(ctx, contact.Criteria{CustomerID: customerID}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, wantContacts, gotContacts)
+	require.Empty(t, replayer.Unused())
+
+	_, err = dns.New(c).SearchingDNSRecords(ctx, "example.com", dns.RecordA, 10, 1, "", "")
+	require.ErrorIs(t, err, rctest.ErrNoInteraction)
+}
+
+// TestReplaySearchShapes locks down the response shapes of the search endpoints as returned by the sandbox.
+func TestReplaySearchShapes(t *testing.T) {
+	cassette, err := rctest.LoadCassette(filepath.Join("testdata", "search.json"))
+	require.NoError(t, err)
+	c := core.New(core.Config{ResellerID: "1", APIKey: "key"}, &http.Client{Transport: rctest.NewReplayer(cassette)})
+	ctx := context.Background()
+
+	records, err := dns.New(c).SearchingDNSRecords(ctx, "example.com", dns.RecordA, 10, 1, "", "")
+	require.NoError(t, err)
+	require.Equal(t, "2", records.Recsindb)
+	require.Equal(t, "2", records.RecsOnPage)
+	require.ElementsMatch(t, []*dns.Record{
+		{TimeToLive: "14400", Status: "Active", Type: "A", Host: "@", Value: "192.0.2.1"},
+		{TimeToLive: "3600", Status: "Active", Type: "A", Host: "www", Value: "192.0.2.2"},
+	}, records.Records)
+
+	contacts, err := contact.New(c).Search(ctx, contact.Criteria{CustomerID: "20401"}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, contacts.TotalMatched)
+	require.Len(t, contacts.Contacts, 1)
+	require.Equal(t, "61201", contacts.Contacts[0].ID)
+	require.Equal(t, "Jane Doe", contacts.Contacts[0].Name)
+	require.Equal(t, "Active", contacts.Contacts[0].StatusSystem)
+}
@@ -0,0 +1,263 @@
+package rctest
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var contactRoles = []string{"registrant", "admin", "tech", "billing"}
+
+func (s *Server) registerContactRoutes() {
+	s.routes["contacts/add"] = s.contactAdd
+	s.routes["contacts/details"] = s.contactGetDetails
+	s.routes["contacts/delete"] = s.contactDelete
+	s.routes["contacts/search"] = s.contactSearch
+	s.routes["contacts/default"] = s.contactDefault
+	s.routes["contacts/modDefault"] = s.contactModDefault
+	s.routes["contacts/validate-registrant"] = s.contactValidateRegistrant
+	s.routes["contacts/set-details"] = s.contactSetDetails
+	s.routes["contacts/dotca/registrantagreement"] = s.contactDotCAAgreement
+}
+
+func attributesOf(params url.Values) map[string]string {
+	ret := map[string]string{}
+	for key := range params {
+		if !strings.HasPrefix(key, "attr-name") {
+			continue
+		}
+		ret[params.Get(key)] = params.Get("attr-value" + strings.TrimPrefix(key, "attr-name"))
+	}
+	return ret
+}
+
+func (s *Server) contact(w http.ResponseWriter, params url.Values) *Contact {
+	id := params.Get("contact-id")
+	c, ok := s.contacts[id]
+	if !ok || c.Status == "Deleted" {
+		writeAPIError(w, "No Entity found for Entityid: %s", id)
+		return nil
+	}
+	return c
+}
+
+func (s *Server) contactAdd(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if _, ok := s.customers[params.Get("customer-id")]; !ok {
+		writeAPIError(w, "Invalid customer-id: %s", params.Get("customer-id"))
+		return
+	}
+	for _, param := range []string{"type", "name", "email", "company", "address-line-1", "city", "country", "zipcode", "phone-cc", "phone"} {
+		if params.Get(param) == "" {
+			writeAPIError(w, "Required parameter missing: %s", param)
+			return
+		}
+	}
+
+	id := s.addContact(&Contact{
+		CustomerID: params.Get("customer-id"),
+		Type:       params.Get("type"),
+		Name:       params.Get("name"),
+		Email:      params.Get("email"),
+		Company:    params.Get("company"),
+		Address:    params.Get("address-line-1"),
+		City:       params.Get("city"),
+		Country:    params.Get("country"),
+		Zipcode:    params.Get("zipcode"),
+		PhoneCC:    params.Get("phone-cc"),
+		Phone:      params.Get("phone"),
+		Attributes: attributesOf(params),
+	})
+	WriteText(w, id)
+}
+
+func (s *Server) contactJSON(c *Contact) map[string]string {
+	return map[string]string{
+		"entityid":      c.ID,
+		"contactid":     c.ID,
+		"type":          c.Type,
+		"customerid":    c.CustomerID,
+		"currentstatus": c.Status,
+		"contactstatus": c.Status,
+		"name":          c.Name,
+		"emailaddr":     c.Email,
+		"company":       c.Company,
+		"address1":      c.Address,
+		"city":          c.City,
+		"country":       c.Country,
+		"zip":           c.Zipcode,
+		"telnocc":       c.PhoneCC,
+		"telno":         c.Phone,
+	}
+}
+
+func (s *Server) contactGetDetails(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if c := s.contact(w, params); c != nil {
+		WriteJSON(w, s.contactJSON(c))
+	}
+}
+
+func (s *Server) contactDelete(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	c := s.contact(w, params)
+	if c == nil {
+		return
+	}
+	for _, o := range s.orders {
+		if o.Status != "Deleted" && slices.Contains([]string{o.RegistrantContactID, o.AdminContactID, o.TechContactID, o.BillingContactID}, c.ID) {
+			writeAPIError(w, "Contact %s is associated with order %s and cannot be deleted", c.ID, o.ID)
+			return
+		}
+	}
+	c.Status = "Deleted"
+	WriteJSON(w, map[string]string{
+		"eaqid":            s.nextID(),
+		"entityid":         c.ID,
+		"actiontype":       "DelContact",
+		"actiontypedesc":   "Deletion of Contact " + c.Name,
+		"actionstatus":     "Success",
+		"actionstatusdesc": "Deletion completed successfully",
+	})
+}
+
+func contactMatches(c *Contact, params url.Values) bool {
+	if c.CustomerID != params.Get("customer-id") {
+		return false
+	}
+	if ids := params["contact-id"]; len(ids) > 0 && !slices.Contains(ids, c.ID) {
+		return false
+	}
+	if statuses := params["status"]; len(statuses) > 0 && !slices.Contains(statuses, c.Status) {
+		return false
+	}
+	if len(params["status"]) == 0 && c.Status == "Deleted" {
+		return false
+	}
+	for param, value := range map[string]string{"name": c.Name, "email": c.Email, "company": c.Company, "type": c.Type} {
+		if want := params.Get(param); want != "" && !strings.EqualFold(want, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) contactSearch(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if params.Get("customer-id") == "" {
+		writeAPIError(w, "Required parameter missing: customer-id")
+		return
+	}
+
+	var matched []*Contact
+	for _, c := range s.contacts {
+		if contactMatches(c, params) {
+			matched = append(matched, c)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *Contact) int { return strings.Compare(a.ID, b.ID) })
+
+	start, end := pageOf(params, len(matched))
+	result := make([]map[string]string, 0, end-start)
+	for _, c := range matched[start:end] {
+		result = append(result, map[string]string{
+			"entity.entityid":      c.ID,
+			"entity.customerid":    c.CustomerID,
+			"entity.currentstatus": c.Status,
+			"contact.type":         c.Type,
+			"contact.name":         c.Name,
+			"contact.emailaddr":    c.Email,
+			"contact.company":      c.Company,
+			"contact.city":         c.City,
+			"contact.country":      c.Country,
+		})
+	}
+	WriteJSON(w, map[string]any{
+		"recsindb":   strconv.Itoa(len(matched)),
+		"recsonpage": strconv.Itoa(len(result)),
+		"result":     result,
+	})
+}
+
+func (s *Server) contactDefault(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	customerID := params.Get("customer-id")
+	cust, ok := s.customers[customerID]
+	if !ok {
+		writeAPIError(w, "Invalid customer-id: %s", customerID)
+		return
+	}
+
+	defaults, ok := s.defaultContacts[customerID]
+	if !ok {
+		id := s.addContact(&Contact{
+			CustomerID: customerID,
+			Name:       cust.Name,
+			Email:      cust.Username,
+			Company:    cust.Company,
+			Country:    cust.Country,
+		})
+		defaults = map[string]string{}
+		for _, role := range contactRoles {
+			defaults[role] = id
+		}
+		s.defaultContacts[customerID] = defaults
+	}
+
+	ret := map[string]any{}
+	for _, t := range params["type"] {
+		set := map[string]any{"type": t}
+		for _, role := range contactRoles {
+			set[role] = defaults[role]
+			if c, ok := s.contacts[defaults[role]]; ok {
+				set[role+"ContactDetails"] = s.contactJSON(c)
+			}
+		}
+		ret[t] = set
+	}
+	WriteJSON(w, ret)
+}
+
+func (s *Server) contactModDefault(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if _, ok := s.customers[params.Get("customer-id")]; !ok {
+		writeAPIError(w, "Invalid customer-id: %s", params.Get("customer-id"))
+		return
+	}
+	if !s.checkContacts(w, params) {
+		return
+	}
+	s.defaultContacts[params.Get("customer-id")] = map[string]string{
+		"registrant": params.Get("reg-contact-id"),
+		"admin":      params.Get("admin-contact-id"),
+		"tech":       params.Get("tech-contact-id"),
+		"billing":    params.Get("billing-contact-id"),
+	}
+	WriteJSON(w, map[string]string{"status": "Success"})
+}
+
+func (s *Server) contactValidateRegistrant(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	c := s.contact(w, params)
+	if c == nil {
+		return
+	}
+	validation := map[string]string{}
+	for _, eligibility := range params["eligibility-criteria"] {
+		validation[eligibility] = "true"
+	}
+	WriteJSON(w, map[string]any{c.ID: validation})
+}
+
+func (s *Server) contactSetDetails(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	c := s.contact(w, params)
+	if c == nil {
+		return
+	}
+	if c.Attributes == nil {
+		c.Attributes = map[string]string{}
+	}
+	for k, v := range attributesOf(params) {
+		c.Attributes[k] = v
+	}
+	WriteText(w, true)
+}
+
+func (s *Server) contactDotCAAgreement(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+	WriteJSON(w, map[string]string{"agreementversion": "2.0", "agreementtext": "CIRA Registrant Agreement"})
+}
@@ -0,0 +1,265 @@
+package rctest
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OTP accepted by customers/authenticate/verify-otp.
+const OTP = "123456"
+
+func (s *Server) registerCustomerRoutes() {
+	s.routes["customers/v2/signup"] = s.customerSignUp
+	s.routes["customers/details"] = s.customerDetailsByUsername
+	s.routes["customers/details-by-id"] = s.customerDetailsByID
+	s.routes["customers/modify"] = s.customerModify
+	s.routes["customers/delete"] = s.customerDelete
+	s.routes["customers/search"] = s.customerSearch
+	s.routes["customers/suspend"] = s.customerSuspension("Suspended")
+	s.routes["customers/unsuspend"] = s.customerSuspension("Active")
+	s.routes["customers/forgot-password"] = s.customerForgotPassword
+	s.routes["customers/v2/change-password"] = s.customerChangePassword
+	s.routes["customers/v2/authenticate"] = s.customerAuthenticate
+	s.routes["customers/generate-token"] = s.customerGenerateToken
+	s.routes["customers/authenticate-token"] = s.customerAuthenticateToken
+	s.routes["customers/authenticate-token-without-history"] = s.customerAuthenticateToken
+	s.routes["customers/authenticate/generate-otp"] = s.customerGenerateOTP
+	s.routes["customers/authenticate/verify-otp"] = s.customerVerifyOTP
+	s.routes["customers/generate-login-token"] = s.customerGenerateLoginToken
+}
+
+func (s *Server) customer(w http.ResponseWriter, id string) *Customer {
+	c, ok := s.customers[id]
+	if !ok || c.Status == "Deleted" {
+		writeAPIError(w, "No Entity found for Entityid: %s", id)
+		return nil
+	}
+	return c
+}
+
+func (s *Server) customerByUsername(username string) *Customer {
+	for _, c := range s.customers {
+		if strings.EqualFold(c.Username, username) && c.Status != "Deleted" {
+			return c
+		}
+	}
+	return nil
+}
+
+func customerJSON(c *Customer) map[string]string {
+	return map[string]string{
+		"customerid":     c.ID,
+		"username":       c.Username,
+		"useremail":      c.Username,
+		"resellerid":     ResellerID,
+		"parentid":       ResellerID,
+		"name":           c.Name,
+		"company":        c.Company,
+		"address1":       c.Address,
+		"city":           c.City,
+		"state":          c.State,
+		"country":        c.Country,
+		"zip":            c.Zipcode,
+		"telnocc":        c.PhoneCC,
+		"telno":          c.Phone,
+		"langpref":       c.Language,
+		"customerstatus": c.Status,
+	}
+}
+
+func (s *Server) customerSignUp(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if s.customerByUsername(params.Get("username")) != nil {
+		writeAPIError(w, "Customer with username %s already exists", params.Get("username"))
+		return
+	}
+	for _, param := range []string{"username", "passwd", "name", "company", "address-line-1", "city", "country", "zipcode", "phone-cc", "phone"} {
+		if params.Get(param) == "" {
+			writeAPIError(w, "Required parameter missing: %s", param)
+			return
+		}
+	}
+
+	id := s.addCustomer(&Customer{
+		Username: params.Get("username"),
+		Password: params.Get("passwd"),
+		Name:     params.Get("name"),
+		Company:  params.Get("company"),
+		Address:  params.Get("address-line-1"),
+		City:     params.Get("city"),
+		State:    params.Get("state"),
+		Country:  params.Get("country"),
+		Zipcode:  params.Get("zipcode"),
+		PhoneCC:  params.Get("phone-cc"),
+		Phone:    params.Get("phone"),
+		Language: params.Get("lang-pref"),
+	})
+	WriteText(w, id)
+}
+
+func (s *Server) customerDetailsByUsername(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	c := s.customerByUsername(params.Get("username"))
+	if c == nil {
+		writeAPIError(w, "No Entity found for username: %s", params.Get("username"))
+		return
+	}
+	WriteJSON(w, customerJSON(c))
+}
+
+func (s *Server) customerDetailsByID(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if c := s.customer(w, params.Get("customer-id")); c != nil {
+		WriteJSON(w, customerJSON(c))
+	}
+}
+
+func (s *Server) customerModify(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	c := s.customer(w, params.Get("customer-id"))
+	if c == nil {
+		return
+	}
+	for param, field := range map[string]*string{
+		"username":       &c.Username,
+		"name":           &c.Name,
+		"company":        &c.Company,
+		"address-line-1": &c.Address,
+		"city":           &c.City,
+		"state":          &c.State,
+		"country":        &c.Country,
+		"zipcode":        &c.Zipcode,
+		"phone-cc":       &c.PhoneCC,
+		"phone":          &c.Phone,
+		"lang-pref":      &c.Language,
+	} {
+		if params.Has(param) {
+			*field = params.Get(param)
+		}
+	}
+	WriteText(w, true)
+}
+
+func (s *Server) customerDelete(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	c := s.customer(w, params.Get("customer-id"))
+	if c == nil {
+		return
+	}
+	for _, o := range s.orders {
+		if o.CustomerID == c.ID && o.Status != "Deleted" {
+			writeAPIError(w, "Customer %s has active orders and cannot be deleted", c.ID)
+			return
+		}
+	}
+	c.Status = "Deleted"
+	WriteText(w, true)
+}
+
+func (s *Server) customerSearch(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	var matched []*Customer
+	for _, c := range s.customers {
+		if c.Status == "Deleted" && !slices.Contains(params["status"], "Deleted") {
+			continue
+		}
+		if ids := params["customer-id"]; len(ids) > 0 && !slices.Contains(ids, c.ID) {
+			continue
+		}
+		if status := params.Get("status"); status != "" && status != c.Status {
+			continue
+		}
+		if username := params.Get("username"); username != "" && !strings.EqualFold(username, c.Username) {
+			continue
+		}
+		if name := params.Get("name"); name != "" && !strings.Contains(strings.ToLower(c.Name), strings.ToLower(name)) {
+			continue
+		}
+		matched = append(matched, c)
+	}
+	slices.SortFunc(matched, func(a, b *Customer) int { return strings.Compare(a.ID, b.ID) })
+
+	start, end := pageOf(params, len(matched))
+	ret := map[string]any{
+		"recsindb":   strconv.Itoa(len(matched)),
+		"recsonpage": strconv.Itoa(end - start),
+	}
+	for i, c := range matched[start:end] {
+		row := map[string]string{}
+		for k, v := range customerJSON(c) {
+			row["customer."+k] = v
+		}
+		ret[strconv.Itoa(i+1)] = row
+	}
+	WriteJSON(w, ret)
+}
+
+func (s *Server) customerSuspension(status string) HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params url.Values) {
+		if c := s.customer(w, params.Get("customer-id")); c != nil {
+			c.Status = status
+			WriteText(w, true)
+		}
+	}
+}
+
+func (s *Server) customerForgotPassword(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	WriteText(w, s.customerByUsername(params.Get("username")) != nil)
+}
+
+func (s *Server) customerChangePassword(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if c := s.customer(w, params.Get("customer-id")); c != nil {
+		c.Password = params.Get("new-passwd")
+		WriteText(w, true)
+	}
+}
+
+func (s *Server) customerAuthenticate(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	c := s.customerByUsername(params.Get("username"))
+	if c == nil || c.Password != params.Get("passwd") {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"status":"ERROR","message":"Invalid username or password","maxAttempts":"5","remainingLoginAttempts":"4"}`))
+		return
+	}
+	WriteJSON(w, customerJSON(c))
+}
+
+func (s *Server) customerGenerateToken(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	c := s.customerByUsername(params.Get("username"))
+	if c == nil || c.Password != params.Get("passwd") {
+		writeAPIError(w, "Invalid username or password")
+		return
+	}
+	token := "token-" + s.nextID()
+	s.tokens[token] = c.ID
+	WriteText(w, token)
+}
+
+func (s *Server) customerAuthenticateToken(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	id, ok := s.tokens[params.Get("token")]
+	if !ok {
+		writeAPIError(w, "Invalid token")
+		return
+	}
+	if c := s.customer(w, id); c != nil {
+		WriteJSON(w, customerJSON(c))
+	}
+}
+
+func (s *Server) customerGenerateOTP(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if c := s.customer(w, params.Get("customerid")); c != nil {
+		WriteText(w, true)
+	}
+}
+
+func (s *Server) customerVerifyOTP(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if c := s.customer(w, params.Get("customerid")); c != nil {
+		WriteText(w, params.Get("otp") == OTP)
+	}
+}
+
+func (s *Server) customerGenerateLoginToken(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if c := s.customer(w, params.Get("customer-id")); c != nil {
+		token := "login-" + s.nextID()
+		s.tokens[token] = c.ID
+		WriteText(w, token)
+	}
+}
@@ -0,0 +1,176 @@
+package rctest
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var recordTypes = map[string]string{
+	"ipv4":  "A",
+	"ipv6":  "AAAA",
+	"cname": "CNAME",
+	"mx":    "MX",
+	"ns":    "NS",
+	"txt":   "TXT",
+	"srv":   "SRV",
+}
+
+func (s *Server) registerDNSRoutes() {
+	s.routes["dns/activate"] = s.dnsActivate
+	s.routes["dns/manage/search-records"] = s.dnsSearchRecords
+	s.routes["dns/manage/delete-record"] = s.dnsDeleteAnyRecord
+	s.routes["dns/manage/update-soa-record"] = s.dnsUpdateSOA
+	for name, recordType := range recordTypes {
+		s.routes["dns/manage/add-"+name+"-record"] = s.dnsAddRecord(recordType)
+		s.routes["dns/manage/update-"+name+"-record"] = s.dnsUpdateRecord(recordType)
+		s.routes["dns/manage/delete-"+name+"-record"] = s.dnsDeleteRecord(recordType)
+	}
+}
+
+func dnsSuccess(w http.ResponseWriter, msg string) {
+	WriteJSON(w, map[string]string{"status": "Success", "msg": msg})
+}
+
+func intParam(params url.Values, key string) int {
+	v, _ := strconv.Atoi(params.Get(key))
+	return v
+}
+
+func (s *Server) dnsActivate(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	if _, ok := s.zones[o.DomainName]; !ok {
+		s.zones[o.DomainName] = []*DNSRecord{}
+	}
+	WriteJSON(w, map[string]string{"status": "Success", "msg": "DNS activated", "zoneid": o.ID, "orderid": o.ID})
+}
+
+func (s *Server) dnsAddRecord(recordType string) HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params url.Values) {
+		domainName := strings.ToLower(params.Get("domain-name"))
+		if domainName == "" {
+			writeAPIError(w, "Invalid domain-name")
+			return
+		}
+		record := &DNSRecord{
+			Type:     recordType,
+			Host:     params.Get("host"),
+			Value:    params.Get("value"),
+			TTL:      intParam(params, "ttl"),
+			Priority: intParam(params, "priority"),
+			Port:     intParam(params, "port"),
+			Weight:   intParam(params, "weight"),
+		}
+		if record.Value == "" {
+			writeAPIError(w, "Invalid value for %s record", recordType)
+			return
+		}
+		for _, r := range s.zones[domainName] {
+			if r.Type == record.Type && r.Host == record.Host && r.Value == record.Value {
+				writeAPIError(w, "Record already exists: %s %s %s", r.Type, r.Host, r.Value)
+				return
+			}
+		}
+		s.zones[domainName] = append(s.zones[domainName], record)
+		dnsSuccess(w, recordType+" record added successfully")
+	}
+}
+
+func (s *Server) dnsUpdateRecord(recordType string) HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params url.Values) {
+		domainName := strings.ToLower(params.Get("domain-name"))
+		for _, r := range s.zones[domainName] {
+			if r.Type == recordType && r.Host == params.Get("host") && r.Value == params.Get("current-value") {
+				r.Value = params.Get("new-value")
+				r.TTL = intParam(params, "ttl")
+				r.Priority = intParam(params, "priority")
+				r.Port = intParam(params, "port")
+				r.Weight = intParam(params, "weight")
+				dnsSuccess(w, recordType+" record updated successfully")
+				return
+			}
+		}
+		writeAPIError(w, "Record does not exist: %s %s %s", recordType, params.Get("host"), params.Get("current-value"))
+	}
+}
+
+func (s *Server) dnsDeleteRecord(recordType string) HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params url.Values) {
+		domainName := strings.ToLower(params.Get("domain-name"))
+		records := s.zones[domainName]
+		idx := slices.IndexFunc(records, func(r *DNSRecord) bool {
+			return r.Type == recordType && r.Host == params.Get("host") && r.Value == params.Get("value")
+		})
+		if idx < 0 {
+			writeAPIError(w, "Record does not exist: %s %s %s", recordType, params.Get("host"), params.Get("value"))
+			return
+		}
+		s.zones[domainName] = slices.Delete(records, idx, idx+1)
+		dnsSuccess(w, recordType+" record deleted successfully")
+	}
+}
+
+func (s *Server) dnsDeleteAnyRecord(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	for domainName, records := range s.zones {
+		idx := slices.IndexFunc(records, func(r *DNSRecord) bool {
+			return r.Host == params.Get("host") && r.Value == params.Get("value")
+		})
+		if idx >= 0 {
+			s.zones[domainName] = slices.Delete(records, idx, idx+1)
+			dnsSuccess(w, "Record deleted successfully")
+			return
+		}
+	}
+	writeAPIError(w, "Record does not exist: %s %s", params.Get("host"), params.Get("value"))
+}
+
+func (s *Server) dnsUpdateSOA(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if _, ok := s.zones[strings.ToLower(params.Get("domain-name"))]; !ok {
+		writeAPIError(w, "Zone does not exist for %s", params.Get("domain-name"))
+		return
+	}
+	dnsSuccess(w, "SOA record updated successfully")
+}
+
+func (s *Server) dnsSearchRecords(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	domainName := strings.ToLower(params.Get("domain-name"))
+	if params.Get("type") == "" {
+		writeAPIError(w, "Required parameter missing: type")
+		return
+	}
+
+	var matched []*DNSRecord
+	for _, r := range s.zones[domainName] {
+		if r.Type != params.Get("type") {
+			continue
+		}
+		if host := params.Get("host"); host != "" && r.Host != host {
+			continue
+		}
+		if value := params.Get("value"); value != "" && r.Value != value {
+			continue
+		}
+		matched = append(matched, r)
+	}
+
+	start, end := pageOf(params, len(matched))
+	ret := map[string]any{
+		"recsindb":   strconv.Itoa(len(matched)),
+		"recsonpage": strconv.Itoa(end - start),
+	}
+	for i, r := range matched[start:end] {
+		ret[strconv.Itoa(i+1)] = map[string]string{
+			"timetolive": strconv.Itoa(r.TTL),
+			"status":     "Active",
+			"type":       r.Type,
+			"host":       r.Host,
+			"value":      r.Value,
+		}
+	}
+	WriteJSON(w, ret)
+}
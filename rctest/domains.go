@@ -0,0 +1,800 @@
+package rctest
+
+import (
+	"cmp"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var defaultNameServers = []string{"ns1.rctest.example", "ns2.rctest.example"}
+
+func (s *Server) registerDomainRoutes() {
+	s.routes["domains/available"] = s.domainAvailable
+	s.routes["domains/premium-check"] = s.domainPremiumCheck
+	s.routes["domains/v5/suggest-names"] = s.domainSuggestNames
+	s.routes["domains/register"] = s.domainRegister
+	s.routes["domains/transfer"] = s.domainTransfer
+	s.routes["domains/validate-transfer"] = s.domainValidateTransfer
+	s.routes["domains/renew"] = s.domainRenew
+	s.routes["domains/search"] = s.domainSearch
+	s.routes["domains/customer-default-ns"] = s.domainCustomerDefaultNS
+	s.routes["domains/orderid"] = s.domainOrderID
+	s.routes["domains/details"] = s.domainDetails
+	s.routes["domains/modify-ns"] = s.domainModifyNS
+	s.routes["domains/add-cns"] = s.domainAddCNS
+	s.routes["domains/modify-cns-name"] = s.domainModifyCNSName
+	s.routes["domains/modify-cns-ip"] = s.domainModifyCNSIP
+	s.routes["domains/delete-cns-ip"] = s.domainDeleteCNSIP
+	s.routes["domains/modify-contact"] = s.domainModifyContact
+	s.routes["domains/modify-privacy-protection"] = s.domainModifyPrivacy
+	s.routes["domains/modify-auth-code"] = s.domainModifyAuthCode
+	s.routes["domains/enable-theft-protection"] = s.domainTheftProtection(true)
+	s.routes["domains/disable-theft-protection"] = s.domainTheftProtection(false)
+	s.routes["domains/locks"] = s.domainLocks
+	s.routes["domains/add-dnssec"] = s.domainAddDNSSEC
+	s.routes["domains/del-dnssec"] = s.domainDeleteDNSSEC
+	s.routes["domains/cancel-transfer"] = s.domainCancelTransfer
+	s.routes["domains/resend-rfa"] = s.domainResendRFA
+	s.routes["domains/raa/resend-verification"] = s.domainResendRAAVerification
+	s.routes["domains/delete"] = s.domainDelete
+	s.routes["domains/restore"] = s.domainRestore
+	s.routes["orders/suspend"] = s.orderSuspension(true)
+	s.routes["orders/unsuspend"] = s.orderSuspension(false)
+	for _, endpoint := range []string{
+		"domains/tel/modify-whois-pref",
+		"domains/uk/release",
+		"domains/de/recheck-ns",
+		"domains/dotxxx/association-details",
+	} {
+		s.routes[endpoint] = s.domainGenericAction
+	}
+}
+
+func (s *Server) action(o *Order, actionType, description string) map[string]string {
+	return map[string]string{
+		"actiontypedesc":   description,
+		"entityid":         o.ID,
+		"actionstatus":     "Success",
+		"status":           "Success",
+		"eaqid":            s.nextID(),
+		"description":      o.DomainName,
+		"actiontype":       actionType,
+		"actionstatusdesc": description + " completed successfully",
+		"customerid":       o.CustomerID,
+	}
+}
+
+func (s *Server) invoicedAction(o *Order, actionType, description string) map[string]string {
+	ret := s.action(o, actionType, description)
+	ret["invoiceid"] = s.nextID()
+	ret["sellingamount"] = "-10.000"
+	ret["unutilisedsellingamount"] = "-10.000"
+	ret["sellingcurrencysymbol"] = "USD"
+	return ret
+}
+
+// order looks up the order-id parameter, writing an API error when it does not exist.
+func (s *Server) order(w http.ResponseWriter, params url.Values) *Order {
+	id := params.Get("order-id")
+	o, ok := s.orders[id]
+	if !ok || o.Status == "Deleted" {
+		writeAPIError(w, "No Entity found for Entityid: %s", id)
+		return nil
+	}
+	return o
+}
+
+func (s *Server) domainAvailable(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	ret := map[string]map[string]string{}
+	for _, name := range params["domain-name"] {
+		for _, tld := range params["tlds"] {
+			domainName := strings.ToLower(name + "." + tld)
+			status := "available"
+			switch {
+			case s.orderByName(domainName) != nil:
+				status = "regthroughus"
+			case s.taken[domainName]:
+				status = "regthroughothers"
+			}
+			ret[domainName] = map[string]string{"classkey": productKeyOf(domainName), "status": status}
+		}
+	}
+	WriteJSON(w, ret)
+}
+
+func (s *Server) domainPremiumCheck(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	ret := map[string]map[string]string{}
+	for _, name := range params["domain-name"] {
+		name = strings.ToLower(name)
+		price, ok := s.premiums[name]
+		if !ok {
+			ret[name] = map[string]string{"premium": "false"}
+			continue
+		}
+		ret[name] = map[string]string{
+			"premium":       "true",
+			"cost_price":    formatPrice(price * 0.8),
+			"selling_price": formatPrice(price),
+			"currency":      "USD",
+		}
+	}
+	WriteJSON(w, ret)
+}
+
+func (s *Server) domainSuggestNames(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	keyword := params.Get("keyword")
+	tlds := []string{"com", "net", "org"}
+	if tld := params.Get("tld-only"); tld != "" {
+		tlds = []string{tld}
+	}
+
+	ret := map[string]map[string]string{}
+	for _, prefix := range []string{"", "my", "get"} {
+		for _, tld := range tlds {
+			domainName := prefix + keyword + "." + tld
+			if s.orderByName(domainName) != nil || s.taken[domainName] {
+				continue
+			}
+			ret[domainName] = map[string]string{"status": "available", "in_ga": "true", "score": "0.9", "spin": prefix}
+		}
+	}
+	WriteJSON(w, ret)
+}
+
+func (s *Server) checkContacts(w http.ResponseWriter, params url.Values) bool {
+	for _, param := range []string{"reg-contact-id", "admin-contact-id", "tech-contact-id", "billing-contact-id"} {
+		id := params.Get(param)
+		if id == "-1" {
+			continue
+		}
+		if _, ok := s.contacts[id]; !ok {
+			writeAPIError(w, "Invalid %s: %s", param, id)
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) newOrderFromParams(w http.ResponseWriter, params url.Values) *Order {
+	domainName := strings.ToLower(params.Get("domain-name"))
+	if domainName == "" || !strings.Contains(domainName, ".") {
+		writeAPIError(w, "Invalid domain-name: %s", domainName)
+		return nil
+	}
+	if _, ok := s.customers[params.Get("customer-id")]; !ok {
+		writeAPIError(w, "Invalid customer-id: %s", params.Get("customer-id"))
+		return nil
+	}
+	if !s.checkContacts(w, params) {
+		return nil
+	}
+
+	return &Order{
+		DomainName:          domainName,
+		CustomerID:          params.Get("customer-id"),
+		NameServers:         slices.Clone(params["ns"]),
+		RegistrantContactID: params.Get("reg-contact-id"),
+		AdminContactID:      params.Get("admin-contact-id"),
+		TechContactID:       params.Get("tech-contact-id"),
+		BillingContactID:    params.Get("billing-contact-id"),
+		PrivacyProtected:    params.Get("protect-privacy") == "true",
+		AutoRenew:           params.Get("auto-renew") == "true",
+		AuthCode:            params.Get("auth-code"),
+		Attributes:          attributesOf(params),
+	}
+}
+
+func (s *Server) domainRegister(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	domainName := strings.ToLower(params.Get("domain-name"))
+	if s.orderByName(domainName) != nil || s.taken[domainName] {
+		writeAPIError(w, "Domain %s is not available for registration", domainName)
+		return
+	}
+	years, err := strconv.Atoi(params.Get("years"))
+	if err != nil || years < 1 || years > 10 {
+		writeAPIError(w, "Invalid years: %s", params.Get("years"))
+		return
+	}
+	if len(params["ns"]) == 0 {
+		writeAPIError(w, "Invalid ns: at least one name server is required")
+		return
+	}
+
+	o := s.newOrderFromParams(w, params)
+	if o == nil {
+		return
+	}
+	o.CreationTime = time.Now()
+	o.EndTime = o.CreationTime.AddDate(years, 0, 0)
+	o.AllowDeletion = true
+	o.TheftProtection = true
+	s.addOrder(o)
+
+	WriteJSON(w, s.invoicedAction(o, "AddNewDomain", "Registration of "+o.DomainName+" for "+strconv.Itoa(years)+" years"))
+}
+
+func (s *Server) domainTransfer(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	domainName := strings.ToLower(params.Get("domain-name"))
+	if s.orderByName(domainName) != nil {
+		writeAPIError(w, "Domain %s is already registered through us", domainName)
+		return
+	}
+
+	o := s.newOrderFromParams(w, params)
+	if o == nil {
+		return
+	}
+	if len(o.NameServers) == 0 {
+		o.NameServers = slices.Clone(defaultNameServers)
+	}
+	// The transfer awaits approval, the registry adds pendingtransfer once it is approved.
+	o.Status = "InActive"
+	s.addOrder(o)
+
+	WriteJSON(w, s.invoicedAction(o, "AddTransferDomain", "Transfer of "+o.DomainName))
+}
+
+func (s *Server) domainValidateTransfer(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	WriteText(w, s.orderByName(params.Get("domain-name")) == nil)
+}
+
+func (s *Server) domainRenew(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	if o.Status != "Active" && o.Status != "Suspended" {
+		writeAPIError(w, "Renewal is not allowed for order %s in status %s", o.ID, o.Status)
+		return
+	}
+
+	years := 1
+	if y, err := strconv.Atoi(params.Get("years")); err == nil && y > 0 {
+		years = y
+	}
+	if expDate := params.Get("exp-date"); expDate != "" && expDate != unix(o.EndTime) {
+		writeAPIError(w, "Invalid exp-date: %s", expDate)
+		return
+	}
+	o.EndTime = o.EndTime.AddDate(years, 0, 0)
+	if params.Has("auto-renew") {
+		o.AutoRenew = params.Get("auto-renew") == "true"
+	}
+
+	WriteJSON(w, s.invoicedAction(o, "RenewDomain", "Renewal of "+o.DomainName+" for "+strconv.Itoa(years)+" years"))
+}
+
+func (s *Server) orderMatches(o *Order, params url.Values) bool {
+	if ids := params["order-id"]; len(ids) > 0 && !slices.Contains(ids, o.ID) {
+		return false
+	}
+	if ids := params["customer-id"]; len(ids) > 0 && !slices.Contains(ids, o.CustomerID) {
+		return false
+	}
+	if statuses := params["status"]; len(statuses) > 0 && !slices.Contains(statuses, o.Status) {
+		return false
+	}
+	if keys := params["product-key"]; len(keys) > 0 && !slices.Contains(keys, o.ProductKey) {
+		return false
+	}
+	if name := params.Get("domain-name"); name != "" && !strings.EqualFold(name, o.DomainName) {
+		return false
+	}
+	if privacy := params.Get("privacy-enabled"); privacy != "" && privacy != strconv.FormatBool(o.PrivacyProtected) {
+		return false
+	}
+	for param, after := range map[string]bool{
+		"expiry-date-start":   true,
+		"expiry-date-end":     false,
+		"creation-date-start": true,
+		"creation-date-end":   false,
+	} {
+		value := params.Get(param)
+		if value == "" {
+			continue
+		}
+		bound, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		t := o.EndTime
+		if strings.HasPrefix(param, "creation") {
+			t = o.CreationTime
+		}
+		if (after && t.Unix() < bound) || (!after && t.Unix() > bound) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) domainSearch(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if params.Get("no-of-records") == "" || params.Get("page-no") == "" {
+		writeAPIError(w, "Required parameter missing: no-of-records, page-no")
+		return
+	}
+
+	var matched []*Order
+	for _, o := range s.sortedOrders() {
+		if o.Status != "Deleted" && s.orderMatches(o, params) {
+			matched = append(matched, o)
+		}
+	}
+
+	start, end := pageOf(params, len(matched))
+	ret := map[string]any{
+		"recsindb":   strconv.Itoa(len(matched)),
+		"recsonpage": strconv.Itoa(end - start),
+	}
+	for i, o := range matched[start:end] {
+		ret[strconv.Itoa(i+1)] = map[string]string{
+			"orders.orderid":           o.ID,
+			"entity.entityid":          o.ID,
+			"entity.description":       o.DomainName,
+			"entity.currentstatus":     o.Status,
+			"entity.customerid":        o.CustomerID,
+			"entitytype.entitytypekey": o.ProductKey,
+			"orders.endtime":           unix(o.EndTime),
+			"orders.creationtime":      unix(o.CreationTime),
+			"orders.autorenew":         strconv.FormatBool(o.AutoRenew),
+			"orders.privacyprotection": strconv.FormatBool(o.PrivacyProtected),
+		}
+	}
+	WriteJSON(w, ret)
+}
+
+func (s *Server) domainCustomerDefaultNS(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if _, ok := s.customers[params.Get("customer-id")]; !ok {
+		writeAPIError(w, "Invalid customer-id: %s", params.Get("customer-id"))
+		return
+	}
+	WriteJSON(w, defaultNameServers)
+}
+
+func (s *Server) domainOrderID(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.orderByName(params.Get("domain-name"))
+	if o == nil {
+		writeAPIError(w, "Website doesn't exist for %s", params.Get("domain-name"))
+		return
+	}
+	WriteText(w, o.ID)
+}
+
+func (s *Server) contactDetails(id string) map[string]any {
+	c, ok := s.contacts[id]
+	if !ok {
+		return nil
+	}
+	return map[string]any{
+		"contactid":     c.ID,
+		"customerid":    c.CustomerID,
+		"type":          c.Type,
+		"name":          c.Name,
+		"emailaddr":     c.Email,
+		"company":       c.Company,
+		"address1":      c.Address,
+		"city":          c.City,
+		"country":       c.Country,
+		"zip":           c.Zipcode,
+		"telnocc":       c.PhoneCC,
+		"telno":         c.Phone,
+		"contactstatus": c.Status,
+		"contacttype":   []string{},
+	}
+}
+
+//nolint:funlen
+func (s *Server) domainDetails(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	// Unlike the actions, the details of deleted orders remain available.
+	o, ok := s.orders[params.Get("order-id")]
+	if !ok {
+		writeAPIError(w, "No Entity found for Entityid: %s", params.Get("order-id"))
+		return
+	}
+
+	options := map[string]bool{}
+	for _, option := range params["options"] {
+		options[option] = true
+	}
+	all := options["All"]
+	with := func(option string) bool { return all || options[option] }
+
+	ret := map[string]any{
+		"orderid":     o.ID,
+		"entityid":    o.ID,
+		"domainname":  o.DomainName,
+		"description": o.DomainName,
+		"classkey":    o.ProductKey,
+		"productkey":  o.ProductKey,
+		"customerid":  o.CustomerID,
+	}
+
+	if with("OrderDetails") {
+		ret["currentstatus"] = o.Status
+		ret["creationtime"] = unix(o.CreationTime)
+		ret["endtime"] = unix(o.EndTime)
+		ret["allowdeletion"] = strconv.FormatBool(o.AllowDeletion)
+		ret["recurring"] = strconv.FormatBool(o.AutoRenew)
+		ret["isprivacyprotected"] = strconv.FormatBool(o.PrivacyProtected)
+		ret["privacyprotectedallowed"] = "true"
+		ret["domsecret"] = o.AuthCode
+		ret["productcategory"] = "domorder"
+		ret["raaVerificationStatus"] = cmp.Or(o.RAAVerificationStatus, "NA")
+		if !o.RAAVerificationStartTime.IsZero() {
+			ret["raaVerificationStartTime"] = unix(o.RAAVerificationStartTime)
+		}
+	}
+	if with("OrderDetails") || with("StatusDetails") {
+		orderStatus := []string{}
+		if o.TheftProtection {
+			orderStatus = append(orderStatus, "transferlock")
+		}
+		if o.CustomerLock {
+			orderStatus = append(orderStatus, "customerlock")
+		}
+		ret["orderstatus"] = orderStatus
+	}
+	if with("DomainStatus") || with("StatusDetails") {
+		ret["domainstatus"] = append([]string{}, o.DomainStatus...)
+	}
+	if with("NsDetails") {
+		ret["noOfNameServers"] = strconv.Itoa(len(o.NameServers))
+		for i, ns := range o.NameServers {
+			ret["ns"+strconv.Itoa(i+1)] = ns
+		}
+	}
+	if with("ContactIds") {
+		ret["registrantcontactid"] = o.RegistrantContactID
+		ret["admincontactid"] = o.AdminContactID
+		ret["techcontactid"] = o.TechContactID
+		ret["billingcontactid"] = o.BillingContactID
+	}
+	for option, contact := range map[string]struct{ key, id string }{
+		"RegistrantContactDetails": {"registrantcontact", o.RegistrantContactID},
+		"AdminContactDetails":      {"admincontact", o.AdminContactID},
+		"TechContactDetails":       {"techcontact", o.TechContactID},
+		"BillingContactDetails":    {"billingcontact", o.BillingContactID},
+	} {
+		if details := s.contactDetails(contact.id); with(option) && details != nil {
+			ret[contact.key] = details
+		}
+	}
+	if with("DNSSECDetails") && len(o.DNSSEC) > 0 {
+		records := make([]map[string]string, len(o.DNSSEC))
+		for i, r := range o.DNSSEC {
+			records[i] = map[string]string{
+				"keytag":     r.KeyTag,
+				"algorithm":  r.Algorithm,
+				"digesttype": r.DigestType,
+				"digest":     r.Digest,
+			}
+		}
+		ret["dnssec"] = records
+	}
+	if with("ChildNameServers") && len(o.ChildNameServers) > 0 {
+		cns := map[string][]string{}
+		for host, ips := range o.ChildNameServers {
+			cns[host] = append([]string{}, ips...)
+		}
+		ret["cns"] = cns
+	}
+
+	WriteJSON(w, ret)
+}
+
+func (s *Server) domainModifyNS(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	ns := params["ns"]
+	if len(ns) == 0 {
+		writeAPIError(w, "Invalid ns: at least one name server is required")
+		return
+	}
+	for _, host := range ns {
+		if strings.HasSuffix(host, "."+o.DomainName) {
+			if _, ok := o.ChildNameServers[host]; !ok {
+				writeAPIError(w, "Invalid ns: %s is not a registered child name server", host)
+				return
+			}
+		}
+	}
+	o.NameServers = slices.Clone(ns)
+	WriteJSON(w, s.action(o, "ModNS", "Modification of Nameservers of "+o.DomainName))
+}
+
+func (s *Server) domainAddCNS(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	cns := strings.ToLower(params.Get("cns"))
+	if !strings.HasSuffix(cns, "."+o.DomainName) {
+		writeAPIError(w, "Invalid cns: %s is not a sub domain of %s", cns, o.DomainName)
+		return
+	}
+	if len(params["ip"]) == 0 {
+		writeAPIError(w, "Invalid ip: at least one ip address is required")
+		return
+	}
+	// Adding an existing child name server adds the IP addresses to it.
+	ips := o.ChildNameServers[cns]
+	for _, ip := range params["ip"] {
+		if slices.Contains(ips, ip) {
+			writeAPIError(w, "Child name server %s already has ip %s", cns, ip)
+			return
+		}
+	}
+	o.ChildNameServers[cns] = append(slices.Clone(ips), params["ip"]...)
+	WriteJSON(w, s.action(o, "AddCNS", "Addition of Child Nameserver "+cns))
+}
+
+func (s *Server) domainModifyCNSName(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	oldCNS, newCNS := strings.ToLower(params.Get("old-cns")), strings.ToLower(params.Get("new-cns"))
+	ips, ok := o.ChildNameServers[oldCNS]
+	if !ok {
+		writeAPIError(w, "Child name server %s not found", oldCNS)
+		return
+	}
+	if !strings.HasSuffix(newCNS, "."+o.DomainName) {
+		writeAPIError(w, "Invalid new-cns: %s is not a sub domain of %s", newCNS, o.DomainName)
+		return
+	}
+	delete(o.ChildNameServers, oldCNS)
+	o.ChildNameServers[newCNS] = ips
+	for i, ns := range o.NameServers {
+		if ns == oldCNS {
+			o.NameServers[i] = newCNS
+		}
+	}
+	WriteJSON(w, s.action(o, "ModCNSName", "Modification of Child Nameserver "+oldCNS))
+}
+
+func (s *Server) domainModifyCNSIP(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	cns := strings.ToLower(params.Get("cns"))
+	ips, ok := o.ChildNameServers[cns]
+	if !ok {
+		writeAPIError(w, "Child name server %s not found", cns)
+		return
+	}
+	idx := slices.Index(ips, params.Get("old-ip"))
+	if idx < 0 {
+		writeAPIError(w, "Invalid old-ip: %s", params.Get("old-ip"))
+		return
+	}
+	ips[idx] = params.Get("new-ip")
+	WriteJSON(w, s.action(o, "ModCNSIp", "Modification of Child Nameserver IP of "+cns))
+}
+
+func (s *Server) domainDeleteCNSIP(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	cns := strings.ToLower(params.Get("cns"))
+	ips, ok := o.ChildNameServers[cns]
+	if !ok {
+		writeAPIError(w, "Child name server %s not found", cns)
+		return
+	}
+	ips = slices.DeleteFunc(slices.Clone(ips), func(ip string) bool { return slices.Contains(params["ip"], ip) })
+	if len(ips) == 0 {
+		if slices.Contains(o.NameServers, cns) {
+			writeAPIError(w, "Child name server %s is in use as a name server of %s", cns, o.DomainName)
+			return
+		}
+		delete(o.ChildNameServers, cns)
+	} else {
+		o.ChildNameServers[cns] = ips
+	}
+	WriteJSON(w, s.action(o, "DelCNSIp", "Deletion of Child Nameserver IP of "+cns))
+}
+
+func (s *Server) domainModifyContact(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil || !s.checkContacts(w, params) {
+		return
+	}
+	o.RegistrantContactID = params.Get("reg-contact-id")
+	o.AdminContactID = params.Get("admin-contact-id")
+	o.TechContactID = params.Get("tech-contact-id")
+	o.BillingContactID = params.Get("billing-contact-id")
+	WriteJSON(w, s.action(o, "ModContact", "Modification of Contact Details of "+o.DomainName))
+}
+
+func (s *Server) domainModifyPrivacy(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	o.PrivacyProtected = params.Get("protect-privacy") == "true"
+	WriteJSON(w, s.action(o, "ModPrivacyProtection", "Modification of Privacy Protection of "+o.DomainName))
+}
+
+func (s *Server) domainModifyAuthCode(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	o.AuthCode = params.Get("auth-code")
+	WriteJSON(w, s.action(o, "ModAuthCode", "Modification of Auth Code of "+o.DomainName))
+}
+
+func (s *Server) domainTheftProtection(enable bool) HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params url.Values) {
+		o := s.order(w, params)
+		if o == nil {
+			return
+		}
+		o.TheftProtection = enable
+		WriteJSON(w, s.action(o, "ModTheftProtection", "Modification of Theft Protection of "+o.DomainName))
+	}
+}
+
+func (s *Server) domainLocks(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	WriteJSON(w, map[string]bool{"transferlock": o.TheftProtection, "customerlock": o.CustomerLock})
+}
+
+func pendingTransfer(o *Order) bool {
+	return o.Status == "InActive"
+}
+
+func (s *Server) domainCancelTransfer(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	if !pendingTransfer(o) {
+		writeAPIError(w, "There is no pending transfer for order %s", o.ID)
+		return
+	}
+	o.Status = "Deleted"
+	o.DomainStatus = nil
+	WriteJSON(w, map[string]string{"status": "Success", "message": "Transfer of " + o.DomainName + " cancelled"})
+}
+
+func (s *Server) domainResendRFA(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	if !pendingTransfer(o) {
+		writeAPIError(w, "There is no pending transfer for order %s", o.ID)
+		return
+	}
+	WriteJSON(w, s.action(o, "ResendRFA", "Resend of Transfer Approval Mail for "+o.DomainName))
+}
+
+func (s *Server) domainResendRAAVerification(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	if o.RAAVerificationStatus != "Pending" {
+		writeAPIError(w, "Registrant verification is not pending for order %s", o.ID)
+		return
+	}
+	WriteText(w, true)
+}
+
+func (s *Server) domainDelete(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	if !o.AllowDeletion {
+		writeAPIError(w, "Deletion is not allowed for order %s", o.ID)
+		return
+	}
+	o.Status = "Deleted"
+	WriteJSON(w, map[string]string{"status": "Success", "eaqid": s.nextID(), "currentaction": s.nextID()})
+}
+
+func (s *Server) domainRestore(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	if o.Status != "Pending Delete Restorable" {
+		writeAPIError(w, "Restoration is not allowed for order %s in status %s", o.ID, o.Status)
+		return
+	}
+	o.Status = "Active"
+	o.EndTime = time.Now().AddDate(1, 0, 0)
+	WriteJSON(w, s.invoicedAction(o, "RestoreDomain", "Restoration of "+o.DomainName))
+}
+
+func (s *Server) orderSuspension(suspend bool) HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, params url.Values) {
+		o := s.order(w, params)
+		if o == nil {
+			return
+		}
+		o.Status = "Active"
+		if suspend {
+			o.Status = "Suspended"
+		}
+		WriteJSON(w, s.action(o, "Suspension", "Suspension of "+o.DomainName))
+	}
+}
+
+func (s *Server) domainGenericAction(w http.ResponseWriter, r *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	WriteJSON(w, s.action(o, "Generic", strings.TrimSuffix(r.URL.Path, ".json")+" of "+o.DomainName))
+}
+
+// dsRecordsOf reads the DS records sent as consecutive groups of keytag, algorithm, digesttype and
+// digest attributes.
+func dsRecordsOf(params url.Values) ([]DSRecord, bool) {
+	var ret []DSRecord
+	for i := 1; params.Has("attr-name" + strconv.Itoa(i)); i += 4 {
+		values := map[string]string{}
+		for j := i; j < i+4; j++ {
+			values[params.Get("attr-name"+strconv.Itoa(j))] = params.Get("attr-value" + strconv.Itoa(j))
+		}
+		r := DSRecord{values["keytag"], values["algorithm"], values["digesttype"], values["digest"]}
+		if r.KeyTag == "" || r.Algorithm == "" || r.DigestType == "" || r.Digest == "" {
+			return nil, false
+		}
+		ret = append(ret, r)
+	}
+	return ret, len(ret) > 0
+}
+
+func (s *Server) domainAddDNSSEC(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	records, ok := dsRecordsOf(params)
+	if !ok {
+		writeAPIError(w, "Invalid DNSSEC attributes")
+		return
+	}
+	for _, r := range records {
+		if !slices.Contains(o.DNSSEC, r) {
+			o.DNSSEC = append(o.DNSSEC, r)
+		}
+	}
+	WriteJSON(w, s.action(o, "AddDNSSEC", "Addition of DNSSEC records of "+o.DomainName))
+}
+
+func (s *Server) domainDeleteDNSSEC(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	records, ok := dsRecordsOf(params)
+	if !ok {
+		writeAPIError(w, "Invalid DNSSEC attributes")
+		return
+	}
+	for _, r := range records {
+		if !slices.Contains(o.DNSSEC, r) {
+			writeAPIError(w, "DS record %s %s %s %s does not exist", r.KeyTag, r.Algorithm, r.DigestType, r.Digest)
+			return
+		}
+	}
+	o.DNSSEC = slices.DeleteFunc(o.DNSSEC, func(r DSRecord) bool { return slices.Contains(records, r) })
+	WriteJSON(w, s.action(o, "DelDNSSEC", "Deletion of DNSSEC records of "+o.DomainName))
+}
@@ -0,0 +1,253 @@
+package rctest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Yearly prices per product key served by the products endpoints.
+var defaultPrices = map[string]float64{
+	"domcno":  9.99,
+	"dotnet":  11.99,
+	"domorg":  10.99,
+	"dombiz":  14.99,
+	"dominfo": 12.99,
+	"dotin":   7.99,
+	"dotco":   24.99,
+	"dotuk":   6.99,
+	"dotde":   5.99,
+	"doteu":   6.49,
+	"dotme":   16.99,
+	"dottv":   29.99,
+	"dotxyz":  1.99,
+	// privacyProductKey is priced with privacyActions instead of the domain actions.
+	privacyProductKey: 2.99,
+}
+
+const privacyProductKey = "privacyprotection"
+
+var (
+	priceActions   = []string{"addnewdomain", "renewdomain", "addtransferdomain", "restoredomain"}
+	privacyActions = []string{"addprivacyprotection", "renewprivacyprotection"}
+)
+
+var countries = map[string]string{
+	"Australia":      "AU",
+	"Canada":         "CA",
+	"France":         "FR",
+	"Germany":        "DE",
+	"India":          "IN",
+	"Indonesia":      "ID",
+	"Japan":          "JP",
+	"Pakistan":       "PK",
+	"United Kingdom": "GB",
+	"United States":  "US",
+}
+
+var states = map[string]map[string]string{
+	"US": {"California": "CA", "New York": "NY", "Texas": "TX", "Washington": "WA"},
+	"IN": {"Karnataka": "KA", "Maharashtra": "MH", "Delhi": "DL"},
+}
+
+var currencies = map[string]map[string]string{
+	"USD": {"currencyunit": "100", "currencyname": "US Dollar"},
+	"EUR": {"currencyunit": "100", "currencyname": "Euro"},
+	"GBP": {"currencyunit": "100", "currencyname": "Pound Sterling"},
+	"INR": {"currencyunit": "100", "currencyname": "Indian Rupee"},
+	"JPY": {"currencyunit": "1", "currencyname": "Japanese Yen"},
+}
+
+// SetPrice sets the yearly price of a product key, e.g. "domcno", or "privacyprotection" for the
+// privacy protection add-on.
+func (s *Server) SetPrice(productKey string, price float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prices[productKey] = price
+}
+
+func (s *Server) registerMiscRoutes() {
+	s.routes["products/customer-price"] = s.productsCustomerPrice
+	s.routes["products/reseller-price"] = s.productsResellerPrice
+	s.routes["products/reseller-cost-price"] = s.productsResellerCostPrice
+	s.routes["products/promo-details"] = s.productsPromoDetails
+	s.routes["country/list"] = countryList
+	s.routes["country/state-list"] = countryStateList
+	s.routes["currency/details"] = currencyDetails
+	s.routes["domainforward/activate"] = s.forwardActivate
+	s.routes["domainforward/manage"] = s.forwardManage
+	s.routes["domainforward/details"] = s.forwardDetails
+	s.routes["domainforward/dns-records"] = s.forwardDNSRecords
+	s.routes["domainforward/delete"] = s.forwardDelete
+	s.routes["domainforward/sub-domain-record/delete"] = s.forwardDeleteSubDomain
+}
+
+func (s *Server) priceTable(format func(price float64, years int) any) map[string]any {
+	ret := map[string]any{}
+	for productKey, price := range s.prices {
+		actions := map[string]any{}
+		productActions := priceActions
+		if productKey == privacyProductKey {
+			productActions = privacyActions
+		}
+		for _, action := range productActions {
+			years := map[string]any{}
+			for y := 1; y <= 10; y++ {
+				years[strconv.Itoa(y)] = format(price, y)
+			}
+			actions[action] = years
+		}
+		ret[productKey] = actions
+	}
+	return ret
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
+
+func (s *Server) productsCustomerPrice(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if _, ok := s.customers[params.Get("customer-id")]; params.Has("customer-id") && !ok {
+		writeAPIError(w, "Invalid customer-id: %s", params.Get("customer-id"))
+		return
+	}
+	WriteJSON(w, s.priceTable(func(price float64, _ int) any {
+		return price
+	}))
+}
+
+func (s *Server) productsResellerPrice(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+	WriteJSON(w, s.priceTable(func(price float64, years int) any {
+		return map[string]string{"pricing": formatPrice(price * float64(years))}
+	}))
+}
+
+func (s *Server) productsResellerCostPrice(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+	WriteJSON(w, s.priceTable(func(price float64, years int) any {
+		return formatPrice(price * 0.8 * float64(years))
+	}))
+}
+
+func (s *Server) productsPromoDetails(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+	WriteJSON(w, map[string]string{})
+}
+
+func countryList(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+	WriteJSON(w, countries)
+}
+
+func countryStateList(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	list, ok := states[params.Get("country-code")]
+	if !ok {
+		list = map[string]string{}
+	}
+	WriteJSON(w, list)
+}
+
+func currencyDetails(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+	WriteJSON(w, currencies)
+}
+
+func (s *Server) setForward(w http.ResponseWriter, params url.Values) *Forward {
+	o := s.order(w, params)
+	if o == nil {
+		return nil
+	}
+	if params.Get("forward-to") == "" {
+		writeAPIError(w, "Required parameter missing: forward-to")
+		return nil
+	}
+	f := &Forward{
+		OrderID:             o.ID,
+		SubDomainPrefix:     params.Get("sub-domain-prefix"),
+		ForwardTo:           params.Get("forward-to"),
+		URLMasking:          params.Get("url-masking") == "true",
+		PathForwarding:      params.Get("path-forwarding") == "true",
+		SubDomainForwarding: params.Get("sub-domain-forwarding") == "true",
+	}
+	s.forwards[o.ID] = f
+	return f
+}
+
+func (s *Server) forwardActivate(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if _, ok := s.forwards[params.Get("order-id")]; ok {
+		writeAPIError(w, "Domain forwarding is already active for order %s", params.Get("order-id"))
+		return
+	}
+	if s.setForward(w, params) != nil {
+		WriteJSON(w, map[string]string{"status": "Success", "message": "Domain forwarding activated"})
+	}
+}
+
+func (s *Server) forwardManage(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if _, ok := s.forwards[params.Get("order-id")]; !ok {
+		writeAPIError(w, "Domain forwarding is not active for order %s", params.Get("order-id"))
+		return
+	}
+	if s.setForward(w, params) != nil {
+		WriteJSON(w, map[string]string{"status": "Success", "message": "Domain forwarding updated"})
+	}
+}
+
+func (s *Server) forwardDetails(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.order(w, params)
+	if o == nil {
+		return
+	}
+	f, ok := s.forwards[o.ID]
+	if !ok {
+		writeAPIError(w, "Domain forwarding is not active for %s", o.DomainName)
+		return
+	}
+	WriteJSON(w, map[string]string{
+		"domainname":          o.DomainName,
+		"forward":             f.ForwardTo,
+		"urlmasking":          strconv.FormatBool(f.URLMasking),
+		"pathforwarding":      strconv.FormatBool(f.PathForwarding),
+		"subdomainforwarding": strconv.FormatBool(f.SubDomainForwarding),
+		"ipaddress":           "127.0.0.1",
+	})
+}
+
+func (s *Server) forwardByDomain(w http.ResponseWriter, params url.Values) *Order {
+	o := s.orderByName(params.Get("domain-name"))
+	if o == nil {
+		writeAPIError(w, "No Entity found for domain-name: %s", params.Get("domain-name"))
+		return nil
+	}
+	if _, ok := s.forwards[o.ID]; !ok {
+		writeAPIError(w, "Domain forwarding is not active for %s", o.DomainName)
+		return nil
+	}
+	return o
+}
+
+func (s *Server) forwardDNSRecords(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	o := s.forwardByDomain(w, params)
+	if o == nil {
+		return
+	}
+	records := []map[string]string{{"timetolive": "7200", "type": "A", "host": "@", "value": "127.0.0.1"}}
+	if prefix := s.forwards[o.ID].SubDomainPrefix; prefix != "" {
+		records = append(records, map[string]string{"timetolive": "7200", "type": "A", "host": prefix, "value": "127.0.0.1"})
+	}
+	WriteJSON(w, records)
+}
+
+func (s *Server) forwardDelete(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	if o := s.forwardByDomain(w, params); o != nil {
+		delete(s.forwards, o.ID)
+		WriteJSON(w, true)
+	}
+}
+
+func (s *Server) forwardDeleteSubDomain(w http.ResponseWriter, _ *http.Request, params url.Values) {
+	f, ok := s.forwards[params.Get("order-id")]
+	if !ok || !strings.EqualFold(f.SubDomainPrefix, params.Get("sub-domain-prefix")) {
+		writeAPIError(w, "No sub-domain forwarding found for %s", params.Get("sub-domain-prefix"))
+		return
+	}
+	f.SubDomainPrefix = ""
+	WriteJSON(w, true)
+}
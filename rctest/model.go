@@ -0,0 +1,309 @@
+package rctest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// Customer is a customer account held by the fake server.
+type Customer struct {
+	ID       string
+	Username string
+	Password string
+	Name     string
+	Company  string
+	Address  string
+	City     string
+	State    string
+	Country  string
+	Zipcode  string
+	PhoneCC  string
+	Phone    string
+	Language string
+	Status   string
+}
+
+// Contact is a domain contact held by the fake server.
+type Contact struct {
+	ID         string
+	CustomerID string
+	Type       string
+	Name       string
+	Email      string
+	Company    string
+	Address    string
+	City       string
+	Country    string
+	Zipcode    string
+	PhoneCC    string
+	Phone      string
+	Status     string
+	Attributes map[string]string
+}
+
+// Order is a domain order held by the fake server.
+type Order struct {
+	ID                  string
+	DomainName          string
+	CustomerID          string
+	ProductKey          string
+	Status              string
+	DomainStatus        []string
+	CreationTime        time.Time
+	EndTime             time.Time
+	NameServers         []string
+	ChildNameServers    map[string][]string
+	RegistrantContactID string
+	AdminContactID      string
+	TechContactID       string
+	BillingContactID    string
+	PrivacyProtected    bool
+	AutoRenew           bool
+	AuthCode            string
+	TheftProtection     bool
+	CustomerLock        bool
+	AllowDeletion       bool
+	Attributes          map[string]string
+	DNSSEC              []DSRecord
+	// RAAVerificationStatus is the registrant verification status, e.g. "Pending", "NA" when empty.
+	RAAVerificationStatus    string
+	RAAVerificationStartTime time.Time
+}
+
+// DSRecord is a DS record published for an order, in the API's string form.
+type DSRecord struct {
+	KeyTag     string
+	Algorithm  string
+	DigestType string
+	Digest     string
+}
+
+// DNSRecord is a record of a DNS zone held by the fake server.
+type DNSRecord struct {
+	Type     string
+	Host     string
+	Value    string
+	TTL      int
+	Priority int
+	Port     int
+	Weight   int
+}
+
+// Forward is the domain forwarding configuration of an order.
+type Forward struct {
+	OrderID             string
+	SubDomainPrefix     string
+	ForwardTo           string
+	URLMasking          bool
+	PathForwarding      bool
+	SubDomainForwarding bool
+}
+
+// AddCustomer stores a customer and returns its ID.
+func (s *Server) AddCustomer(c Customer) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addCustomer(&c)
+}
+
+func (s *Server) addCustomer(c *Customer) string {
+	if c.ID == "" {
+		c.ID = s.nextID()
+	}
+	if c.Status == "" {
+		c.Status = "Active"
+	}
+	s.customers[c.ID] = c
+	return c.ID
+}
+
+// AddContact stores a contact and returns its ID.
+func (s *Server) AddContact(c Contact) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addContact(&c)
+}
+
+func (s *Server) addContact(c *Contact) string {
+	if c.ID == "" {
+		c.ID = s.nextID()
+	}
+	if c.Type == "" {
+		c.Type = "Contact"
+	}
+	if c.Status == "" {
+		c.Status = "Active"
+	}
+	s.contacts[c.ID] = c
+	return c.ID
+}
+
+// AddOrder stores a domain order and returns its ID.
+func (s *Server) AddOrder(o Order) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addOrder(&o)
+}
+
+func (s *Server) addOrder(o *Order) string {
+	if o.ID == "" {
+		o.ID = s.nextID()
+	}
+	if o.Status == "" {
+		o.Status = "Active"
+	}
+	if o.CreationTime.IsZero() {
+		o.CreationTime = time.Now()
+	}
+	if o.EndTime.IsZero() {
+		o.EndTime = o.CreationTime.AddDate(1, 0, 0)
+	}
+	if o.ProductKey == "" {
+		o.ProductKey = productKeyOf(o.DomainName)
+	}
+	if o.ChildNameServers == nil {
+		o.ChildNameServers = map[string][]string{}
+	}
+	s.orders[o.ID] = o
+	return o.ID
+}
+
+// Order returns a copy of the order.
+func (s *Server) Order(id string) (Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orders[id]
+	if !ok {
+		return Order{}, false
+	}
+	return *o, true
+}
+
+// UpdateOrder applies fn to the stored order, e.g. to simulate an expiry or a registry status change.
+func (s *Server) UpdateOrder(id string, fn func(o *Order)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orders[id]
+	if ok {
+		fn(o)
+	}
+	return ok
+}
+
+// Contact returns a copy of the contact.
+func (s *Server) Contact(id string) (Contact, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.contacts[id]
+	if !ok {
+		return Contact{}, false
+	}
+	return *c, true
+}
+
+// UpdateContact applies fn to the stored contact, e.g. to simulate a change made outside the API.
+func (s *Server) UpdateContact(id string, fn func(c *Contact)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.contacts[id]
+	if ok {
+		fn(c)
+	}
+	return ok
+}
+
+// Customer returns a copy of the customer.
+func (s *Server) Customer(id string) (Customer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.customers[id]
+	if !ok {
+		return Customer{}, false
+	}
+	return *c, true
+}
+
+// Records returns a copy of the DNS records of the zone.
+func (s *Server) Records(domainName string) []DNSRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := make([]DNSRecord, 0, len(s.zones[domainName]))
+	for _, r := range s.zones[domainName] {
+		ret = append(ret, *r)
+	}
+	return ret
+}
+
+// SetTaken marks a domain name as registered through another registrar.
+func (s *Server) SetTaken(domainName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taken[strings.ToLower(domainName)] = true
+}
+
+// SetPremium marks a domain name as premium with the given selling price in USD.
+func (s *Server) SetPremium(domainName string, price float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.premiums[strings.ToLower(domainName)] = price
+}
+
+func (s *Server) orderByName(domainName string) *Order {
+	for _, o := range s.orders {
+		if strings.EqualFold(o.DomainName, domainName) && o.Status != "Deleted" {
+			return o
+		}
+	}
+	return nil
+}
+
+func (s *Server) sortedOrders() []*Order {
+	ret := make([]*Order, 0, len(s.orders))
+	for _, o := range s.orders {
+		ret = append(ret, o)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, _ := strconv.Atoi(ret[i].ID)
+		b, _ := strconv.Atoi(ret[j].ID)
+		return a < b
+	})
+	return ret
+}
+
+// productKeyOf returns the product key of the domain's TLD, or one made up from its last label.
+func productKeyOf(domainName string) string {
+	if info, ok := core.DefaultTLDCatalog().LookupDomain(domainName); ok {
+		return string(info.Key)
+	}
+	labels := strings.Split(strings.ToLower(domainName), ".")
+	return "dot" + labels[len(labels)-1]
+}
+
+func unix(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+func pageOf(params map[string][]string, total int) (start, end int) {
+	limit, _ := strconv.Atoi(first(params["no-of-records"]))
+	page, _ := strconv.Atoi(first(params["page-no"]))
+	if limit <= 0 {
+		limit = total
+	}
+	if page <= 0 {
+		page = 1
+	}
+	start = min((page-1)*limit, total)
+	end = min(start+limit, total)
+	return start, end
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
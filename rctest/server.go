@@ -0,0 +1,186 @@
+// Package rctest provides an in-process fake of the ResellerClub API for hermetic tests.
+//
+// The fake keeps a stateful in-memory model: registering a domain creates an order, DNS records
+// added through dns/manage show up in search-records, contacts and customers get IDs, and so on.
+//
+//	srv := rctest.NewServer()
+//	defer srv.Close()
+//	c := core.New(srv.Config(), srv.Client())
+package rctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mrehanabbasi/go-logicboxes/core"
+)
+
+// Credentials accepted by the fake server.
+const (
+	ResellerID = "123456"
+	APIKey     = "rctest-api-key"
+)
+
+// HandlerFunc handles a single API call. params holds both query and form parameters.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request, params url.Values)
+
+// Server is a fake ResellerClub API served over HTTP.
+type Server struct {
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	routes    map[string]HandlerFunc
+	overrides map[string]HandlerFunc
+	calls     []Call
+
+	lastID          int
+	customers       map[string]*Customer
+	contacts        map[string]*Contact
+	orders          map[string]*Order
+	zones           map[string][]*DNSRecord
+	forwards        map[string]*Forward
+	taken           map[string]bool
+	defaultContacts map[string]map[string]string
+	tokens          map[string]string
+	prices          map[string]float64
+	premiums        map[string]float64
+}
+
+// Call is a recorded API call.
+type Call struct {
+	Method string
+	Path   string
+	Params url.Values
+}
+
+// NewServer starts a fake server. Callers must Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		overrides:       map[string]HandlerFunc{},
+		lastID:          10000,
+		customers:       map[string]*Customer{},
+		contacts:        map[string]*Contact{},
+		orders:          map[string]*Order{},
+		zones:           map[string][]*DNSRecord{},
+		forwards:        map[string]*Forward{},
+		taken:           map[string]bool{},
+		defaultContacts: map[string]map[string]string{},
+		tokens:          map[string]string{},
+		prices:          maps.Clone(defaultPrices),
+		premiums:        map[string]float64{},
+	}
+	s.routes = map[string]HandlerFunc{}
+	s.registerDomainRoutes()
+	s.registerDNSRoutes()
+	s.registerContactRoutes()
+	s.registerCustomerRoutes()
+	s.registerMiscRoutes()
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the API root of the fake server, suitable for core.Config.BaseURL.
+func (s *Server) URL() string {
+	return s.srv.URL + "/api"
+}
+
+// Client returns an HTTP client configured for the fake server.
+func (s *Server) Client() *http.Client {
+	return s.srv.Client()
+}
+
+// Config returns a core.Config pointing at the fake server with valid credentials.
+func (s *Server) Config() core.Config {
+	return core.Config{
+		ResellerID: ResellerID,
+		APIKey:     APIKey,
+		BaseURL:    s.URL(),
+	}
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Handle overrides the endpoint, e.g. "domains/details", with a custom handler.
+// It is useful to simulate errors or response shapes not covered by the fake.
+func (s *Server) Handle(endpoint string, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[endpoint] = h
+}
+
+// Calls returns the API calls received so far.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/"), ".json")
+	if err := r.ParseForm(); err != nil {
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	params := r.Form
+
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{Method: r.Method, Path: endpoint, Params: params})
+	override := s.overrides[endpoint]
+	route := s.routes[endpoint]
+	s.mu.Unlock()
+
+	if params.Get("auth-userid") != ResellerID || params.Get("api-key") != APIKey {
+		WriteError(w, http.StatusInternalServerError, "Authentication failed for auth-userid "+params.Get("auth-userid"))
+		return
+	}
+
+	switch {
+	case override != nil:
+		override(w, r, params)
+	case route != nil:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		route(w, r, params)
+	default:
+		WriteError(w, http.StatusNotFound, "Unknown API "+endpoint)
+	}
+}
+
+func (s *Server) nextID() string {
+	s.lastID++
+	return strconv.Itoa(s.lastID)
+}
+
+// WriteJSON writes v as a JSON response with status 200.
+func WriteJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// WriteText writes a plain response with status 200, like the API does for IDs and booleans.
+func WriteText(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = fmt.Fprint(w, v)
+}
+
+// WriteError writes an API error in the {"status":"ERROR","message":...} shape.
+func WriteError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(core.JSONStatusResponse{Status: "ERROR", Message: message})
+}
+
+func writeAPIError(w http.ResponseWriter, format string, args ...any) {
+	WriteError(w, http.StatusInternalServerError, fmt.Sprintf(format, args...))
+}
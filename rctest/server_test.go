@@ -0,0 +1,151 @@
+package rctest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/mrehanabbasi/go-logicboxes/contact"
+	"github.com/mrehanabbasi/go-logicboxes/core"
+	"github.com/mrehanabbasi/go-logicboxes/customer"
+	"github.com/mrehanabbasi/go-logicboxes/dns"
+	"github.com/mrehanabbasi/go-logicboxes/domain"
+	"github.com/mrehanabbasi/go-logicboxes/domainforward"
+	"github.com/mrehanabbasi/go-logicboxes/general"
+	"github.com/mrehanabbasi/go-logicboxes/pricing"
+	"github.com/mrehanabbasi/go-logicboxes/rctest"
+	"github.com/stretchr/testify/require"
+)
+
+func newCore(t *testing.T) (*rctest.Server, core.Core) {
+	t.Helper()
+	srv := rctest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv, core.New(srv.Config(), srv.Client())
+}
+
+func TestProvisioningFlow(t *testing.T) {
+	srv, c := newCore(t)
+	ctx := context.Background()
+
+	form := &customer.SignUpForm{
+		Username:         "jane@example.com",
+		Password:         "Secr3t.pass",
+		Name:             "Jane Doe",
+		Company:          "Example Inc",
+		Address:          "1 Main St",
+		City:             "Springfield",
+		State:            "CA",
+		Country:          "US",
+		Zipcode:          "90210",
+		LanguageCode:     "en",
+		PhoneCountryCode: "01",
+		Phone:            "5551234",
+	}
+	require.NoError(t, customer.New(c).SignUp(ctx, form))
+	require.NotEmpty(t, form.CustomerID)
+
+	detail := &contact.Detail{
+		Type:             "Contact",
+		CustomerID:       form.CustomerID,
+		Name:             "Jane Doe",
+		Email:            "jane@example.com",
+		Company:          "Example Inc",
+		Address:          "1 Main St",
+		City:             "Springfield",
+		CountryCode:      "US",
+		Zipcode:          "90210",
+		PhoneCountryCode: "1",
+		Phone:            "5551234",
+	}
+	require.NoError(t, contact.New(c).Add(ctx, detail, nil))
+	require.NotEmpty(t, detail.ID)
+
+	d := domain.New(c)
+	reg, err := d.Register(ctx, "example.com", 1, []string{"ns1.example.net", "ns2.example.net"},
+		form.CustomerID, detail.ID, detail.ID, detail.ID, detail.ID, "NoInvoice",
+		false, false, false, "", "", 0, false)
+	require.NoError(t, err)
+	require.Equal(t, "Success", reg.ActionStatus)
+
+	orderID, err := d.GetOrderID(ctx, "example.com")
+	require.NoError(t, err)
+	require.Equal(t, reg.EntityID, orderID)
+
+	order, err := d.GetRegistrationOrderDetails(ctx, orderID, []domain.DetailOption{domain.DetailAll})
+	require.NoError(t, err)
+	require.Equal(t, "example.com", order.DomainName)
+	require.Equal(t, form.CustomerID, order.CustomerID)
+
+	z := dns.New(c)
+	_, err = z.ActivatingDNSService(ctx, orderID)
+	require.NoError(t, err)
+	_, err = z.AddingIPv4AddressRecord(ctx, "example.com", "192.0.2.1", "www", 3600)
+	require.NoError(t, err)
+	_, err = z.AddingTXTRecord(ctx, "example.com", "v=spf1 -all", "@", 3600)
+	require.NoError(t, err)
+
+	records, err := z.SearchingDNSRecords(ctx, "example.com", dns.RecordA, 10, 1, "", "")
+	require.NoError(t, err)
+	require.Len(t, records.Records, 1)
+	require.Equal(t, "192.0.2.1", records.Records[0].Value)
+	require.Len(t, srv.Records("example.com"), 2)
+
+	f := domainforward.New(c)
+	_, err = f.ActivatingDomainForwardingService(ctx, orderID, "", "https://example.org", false, "", "", false, true)
+	require.NoError(t, err)
+	fwd, err := f.GettingDetailsDomainForwardingService(ctx, orderID, false)
+	require.NoError(t, err)
+	require.True(t, fwd.PathForwarding.ToBool())
+}
+
+func TestNotFound(t *testing.T) {
+	_, c := newCore(t)
+
+	_, err := domain.New(c).GetRegistrationOrderDetails(context.Background(), "42", []domain.DetailOption{domain.DetailAll})
+	require.ErrorIs(t, err, core.ErrNotFound)
+
+	var apiErr *core.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, "domains", apiErr.Namespace)
+	require.Equal(t, "details", apiErr.APIName)
+}
+
+func TestBadCredentials(t *testing.T) {
+	srv := rctest.NewServer()
+	defer srv.Close()
+	cfg := srv.Config()
+	cfg.APIKey = "wrong"
+
+	_, err := domain.New(core.New(cfg, srv.Client())).GetOrderID(context.Background(), "example.com")
+	require.ErrorIs(t, err, core.ErrAuth)
+}
+
+func TestHandleOverride(t *testing.T) {
+	srv, c := newCore(t)
+	srv.Handle("domains/orderid", func(w http.ResponseWriter, _ *http.Request, _ url.Values) {
+		rctest.WriteError(w, http.StatusTooManyRequests, "Too many requests")
+	})
+
+	_, err := domain.New(c).GetOrderID(context.Background(), "example.com")
+	require.ErrorIs(t, err, core.ErrRateLimited)
+	require.Equal(t, "domains/orderid", srv.Calls()[0].Path)
+}
+
+func TestGeneralAndPricing(t *testing.T) {
+	srv, c := newCore(t)
+	ctx := context.Background()
+
+	g, err := general.New(ctx, c)
+	require.NoError(t, err)
+	require.Equal(t, "India", g.CountryName(general.CountryIndia))
+	require.Equal(t, "US Dollar", g.CurrencyOf(general.IsoUSD).Name)
+
+	customerID := srv.AddCustomer(rctest.Customer{Username: "jane@example.com", Name: "Jane Doe"})
+	srv.SetPrice("domcno", 12.5)
+	prices, err := pricing.New(c).GettingCustomerPricing(ctx, customerID)
+	require.NoError(t, err)
+	require.InDelta(t, 12.5, prices["domcno"]["addnewdomain"]["2"], 0.001)
+}